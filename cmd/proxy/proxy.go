@@ -2,30 +2,86 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc"
 
+	"github.com/swarpf/proxy/pkg/accountdiff"
+	"github.com/swarpf/proxy/pkg/accountstate"
+	"github.com/swarpf/proxy/pkg/derived"
+	"github.com/swarpf/proxy/pkg/dnsresponder"
 	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels"
 	"github.com/swarpf/proxy/pkg/pmanager"
+	"github.com/swarpf/proxy/pkg/runeeval"
+	"github.com/swarpf/proxy/pkg/runlog"
+	"github.com/swarpf/proxy/pkg/schemadrift"
+	"github.com/swarpf/proxy/pkg/store"
+	"github.com/swarpf/proxy/pkg/swexport"
 	"github.com/swarpf/proxy/pkg/swproxy"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "query" {
+		os.Exit(runQuery(os.Args[2:]))
+	}
+
 	pflag.String("proxy_listen_addr", "0.0.0.0:8010", "Listen address for the http proxy")
+	pflag.String("transparent_listen_addr", "", "Listen address for redirected connections in transparent mode (disabled if empty)")
+	pflag.String("socks_listen_addr", "", "Listen address for the SOCKS5 proxy (disabled if empty)")
+	pflag.String("dns_listen_addr", "", "Listen address for the DNS responder that redirects the game hosts to the proxy (disabled if empty)")
+	pflag.String("dns_upstream_resolver", "1.1.1.1:53", "Upstream resolver for all queries that are not redirected by the DNS responder")
+	pflag.String("dns_proxy_ip", "", "IP address returned for redirected game hosts (detected automatically if empty)")
+	pflag.StringSlice("dns_game_listen_addrs", []string{"0.0.0.0:80", "0.0.0.0:443"}, "Listen addresses for game connections redirected by the DNS responder")
 	pflag.String("proxyapi_listen_addr", "0.0.0.0:11000", "Listen address for the proxy API")
+	pflag.Bool("proxyapi_tls", false, "Enable TLS for the proxy API")
+	pflag.String("proxyapi_tls_cert", "", "TLS certificate file for the proxy API (issued by the proxy CA if empty)")
+	pflag.String("proxyapi_tls_key", "", "TLS private key file for the proxy API (issued by the proxy CA if empty)")
+	pflag.StringSlice("proxyapi_tls_hosts", []string{"localhost", "127.0.0.1"}, "Host names and IPs of the proxy API certificate issued by the proxy CA")
+	pflag.String("proxyapi_client_ca", "", "Require proxy API consumers to present a client certificate signed by this CA file ('proxy' to use the proxy CA)")
+	pflag.StringSlice("proxyapi_tokens", []string{}, "Bearer tokens for proxy API consumers (<token>[=<command glob>|<command glob>...])")
+	pflag.Bool("proxyapi_consumer_tls", false, "Use TLS when connecting to proxy API consumers")
+	pflag.String("config", "", "Configuration file (yaml, json or toml) that is watched for changes, reloaded on SIGHUP as well")
+	pflag.Duration("shutdown_timeout", 10*time.Second, "Maximum time to wait for in-flight exchanges and event deliveries on shutdown")
+	pflag.Bool("account_state", true, "Track the state of the player account and serve it over the proxy API")
+	pflag.String("swex_export_dir", "", "Directory for profiles in the SW Exporter format, written on every login and refreshed on changes (disabled if empty)")
+	pflag.Bool("swex_export_normalize", false, "Normalize the runes and artifacts of units in exported profiles to arrays ordered by slot")
+	pflag.Bool("derived_events", true, "Publish derived events like derived.RuneDropped that are extracted from the game api events")
+	pflag.Bool("account_diff", true, "Compare consecutive snapshots of the account and publish the changes as derived.AccountDiff events")
+	pflag.Bool("rune_evaluation", true, "Evaluate dropped and upgraded runes and publish them as derived.RuneEvaluated events")
+	pflag.StringSlice("rune_rules", []string{}, "Keep/sell rules for evaluated runes, the first matching rule applies (<keep|sell>[:<condition>;...])")
+	pflag.String("run_log_csv", "", "CSV file that dungeon and trial tower runs are appended to (disabled if empty)")
+	pflag.String("run_stats_csv", "", "CSV file with the aggregated runs per dungeon stage and trial tower floor (disabled if empty)")
+	pflag.String("store", "", "SQLite database that all events and normalised game data are stored in (disabled if empty, see `proxy query`)")
+	pflag.Bool("schema_drift", true, "Learn the schema of the game api responses and report new, missing and changed fields")
+	pflag.Int("schema_drift_min_observations", 5, "Number of responses of a command that are learned before schema drift is reported")
+	pflag.String("monster_data", "", "Monster master data file that replaces the bundled master data (see gamemodels.LoadMonsterData)")
 	pflag.Bool("verbose", false, "Enable verbose logging")
 	pflag.Bool("log_pretty_print", false, "Enable human readable log")
 	pflag.Bool("intercept_https", false, "Enable HTTPS interception")
 	pflag.String("certificate_directory", "./certs/", "HTTPS certificate directory (only used when HTTPS interception is enabled)")
 	pflag.Bool("force_http_downgrade", false, "Forces the use of HTTP when talking to the API")
+	pflag.StringSlice("allowed_networks", []string{}, "Networks (CIDR) or IP addresses that are allowed to use the proxy (all if empty)")
+	pflag.String("proxy_auth", "", "Require clients to authenticate with these credentials (<username>:<password>)")
+	pflag.Bool("game_hosts_only", false, "Refuse to proxy any host except the game servers")
+	pflag.StringSlice("upstream_proxy", []string{}, "Route matching hosts through an upstream proxy (<host pattern>=<http|socks5 url>)")
 	pflag.Parse()
 
 	viper.SetEnvPrefix("swarpf_proxy")
@@ -35,14 +91,18 @@ func main() {
 	}
 	viper.AutomaticEnv()
 
+	if configFile := viper.GetString("config"); configFile != "" {
+		viper.SetConfigFile(configFile)
+		if err := viper.ReadInConfig(); err != nil {
+			log.Fatal().Err(err).Str("config", configFile).Msg("Failed to read configuration file")
+		}
+	}
+
 	listenAddress := viper.GetString("proxy_listen_addr")
 	proxyApiAddress := viper.GetString("proxyapi_listen_addr")
 
 	// setup logging
-	zerolog.SetGlobalLevel(zerolog.InfoLevel)
-	if viper.GetBool("verbose") {
-		zerolog.SetGlobalLevel(zerolog.TraceLevel)
-	}
+	applyLogLevel()
 
 	if viper.GetBool("log_pretty_print") {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339})
@@ -55,18 +115,146 @@ func main() {
 		Str("listenAddress", listenAddress).
 		Msgf("Server listening to %s", listenAddress)
 
+	proxyConfiguration, err := readProxyConfiguration()
+	if err != nil {
+		mainLogger.Fatal().Err(err).Msg("Invalid proxy configuration")
+	}
+
+	if monsterDataFile := viper.GetString("monster_data"); monsterDataFile != "" {
+		loadMonsterData(monsterDataFile)
+	} else {
+		mainLogger.Info().Str("version", gamemodels.MonsterDataVersion()).Msg("Using the bundled monster master data")
+	}
+
 	apiEvents := make(chan events.ApiEventMsg, 1)
 
+	var eventStore *store.Store
+	if storeFile := viper.GetString("store"); storeFile != "" {
+		if eventStore, err = store.Open(storeFile); err != nil {
+			mainLogger.Fatal().Err(err).Str("store", storeFile).Msg("Failed to open the store")
+		}
+	}
+
 	// initialize proxy manager
-	pm := pmanager.NewProxyManager(proxyApiAddress)
+	apiConfiguration := proxyApiConfiguration()
+
+	var tracker *accountstate.Tracker
+	if viper.GetBool("account_state") {
+		tracker = accountstate.New()
+		apiConfiguration.Services = append(apiConfiguration.Services, pmanager.Service{
+			Register: func(s *grpc.Server) { accountstate.RegisterServer(s, tracker) },
+			// the account state contains everything that is sent on login
+			Commands: map[string]string{accountstate.FullMethod: "HubUserLogin"},
+		})
+	}
+
+	// the detector publishes through the proxy manager, which needs to know the detector service on creation
+	var pm *pmanager.ProxyManager
+	var detector *schemadrift.Detector
+	if viper.GetBool("schema_drift") {
+		var schemas schemadrift.SchemaStore
+		if eventStore != nil {
+			schemas = eventStore
+		}
+
+		detector, err = schemadrift.New(events.PublisherFunc(func(topic string, msg events.ApiEventMsg) {
+			pm.Publish(topic, msg)
+		}), schemadrift.Configuration{
+			MinObservations: viper.GetInt("schema_drift_min_observations"),
+			Schemas:         schemas,
+		})
+		if err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to create the schema drift detector")
+		}
+		// drift of all commands is reported, so only callers that may receive all commands may call the service
+		apiConfiguration.Services = append(apiConfiguration.Services, pmanager.Service{
+			Register: func(s *grpc.Server) { schemadrift.RegisterServer(s, detector) },
+		})
+	}
+
+	pm = pmanager.NewProxyManager(proxyApiAddress, apiConfiguration)
+	if tracker != nil {
+		if err := pm.RegisterPlugin(tracker); err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to register the account state tracker")
+		}
+	}
+
+	if viper.GetBool("derived_events") {
+		if err := pm.RegisterPlugin(derived.New(pm)); err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to register the derived events")
+		}
+	}
+
+	var evaluator *runeeval.Evaluator
+	if viper.GetBool("rune_evaluation") {
+		runeRules, err := readRuneRules()
+		if err != nil {
+			mainLogger.Fatal().Err(err).Msg("Invalid rune rule")
+		}
+
+		evaluator = runeeval.New(pm, runeRules)
+		if err := pm.RegisterPlugin(evaluator); err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to register the rune evaluator")
+		}
+	}
+
+	if eventStore != nil {
+		if err := pm.RegisterPlugin(eventStore); err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to register the store")
+		}
+	}
+
+	if detector != nil {
+		if err := pm.RegisterPlugin(detector); err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to register the schema drift detector")
+		}
+	}
+
+	if viper.GetBool("account_diff") {
+		var snapshots accountdiff.SnapshotStore
+		if eventStore != nil {
+			snapshots = eventStore
+		}
+		if err := pm.RegisterPlugin(accountdiff.New(pm, snapshots)); err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to register the account diff")
+		}
+	}
+
+	if runLogFile, runStatsFile := viper.GetString("run_log_csv"), viper.GetString("run_stats_csv"); runLogFile != "" || runStatsFile != "" || eventStore != nil {
+		runLogger := runlog.New()
+		if eventStore != nil {
+			runLogger.AddSink(eventStore)
+		}
+		if runLogFile != "" {
+			if err := runLogger.LoadCSV(runLogFile); err != nil {
+				mainLogger.Fatal().Err(err).Msg("Failed to read the run log")
+			}
+			runLogger.AddSink(runlog.NewCSVSink(runLogFile))
+		}
+		if runStatsFile != "" {
+			runLogger.AddSink(runlog.NewStatsFileSink(runLogger, runStatsFile))
+		}
+
+		if err := pm.RegisterPlugin(runLogger); err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to register the run logger")
+		}
+	}
+
+	if exportDirectory := viper.GetString("swex_export_dir"); exportDirectory != "" {
+		exporter, err := swexport.New(swexport.Configuration{
+			Directory: exportDirectory,
+			Normalize: viper.GetBool("swex_export_normalize"),
+		})
+		if err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to create the profile exporter")
+		}
+		if err := pm.RegisterPlugin(exporter); err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to register the profile exporter")
+		}
+	}
 
 	// initialize proxy
-	swProxy := swproxy.New(apiEvents, swproxy.ProxyConfiguration{
-		CertificateDirectory: viper.GetString("certificate_directory"),
-		InterceptHttps:       viper.GetBool("intercept_https"),
-		ForceHttpDowngrade:   viper.GetBool("force_http_downgrade"),
-		Verbose:              viper.GetBool("verbose"),
-	})
+	swProxy := swproxy.New(apiEvents, proxyConfiguration)
 	httpProxy := swProxy.CreateProxy()
 
 	server := &http.Server{Addr: listenAddress, Handler: httpProxy}
@@ -83,40 +271,315 @@ func main() {
 		}
 	}()
 
+	// listeners that are closed first on shutdown, so no new exchanges are started
+	var listeners []io.Closer
+
+	if transparentAddress := viper.GetString("transparent_listen_addr"); transparentAddress != "" {
+		listeners = append(listeners, listenTransparent(swProxy, httpProxy, transparentAddress))
+	}
+
+	if socksAddress := viper.GetString("socks_listen_addr"); socksAddress != "" {
+		socksListener, err := net.Listen("tcp", socksAddress)
+		if err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to create SOCKS proxy listener")
+		}
+		listeners = append(listeners, socksListener)
+
+		mainLogger.Info().
+			Str("socksAddress", socksAddress).
+			Msgf("SOCKS proxy listening to %s", socksAddress)
+
+		go func() {
+			err := swProxy.ServeSocks(socksListener, httpProxy)
+			mainLogger.Info().Str("reason", err.Error()).Msg("SOCKS proxy stopped listening")
+		}()
+	}
+
+	if dnsAddress := viper.GetString("dns_listen_addr"); dnsAddress != "" {
+		proxyIp := net.ParseIP(viper.GetString("dns_proxy_ip"))
+		if proxyIp == nil {
+			proxyIp = detectOutboundIp(viper.GetString("dns_upstream_resolver"))
+		}
+
+		responder, err := dnsresponder.New(dnsresponder.Configuration{
+			UpstreamResolver: viper.GetString("dns_upstream_resolver"),
+			ProxyIp:          proxyIp,
+			AllowClient:      swProxy.IsClientAllowed,
+		}, swproxy.IsGameHost)
+		if err != nil {
+			mainLogger.Fatal().Err(err).Msg("Failed to create DNS responder")
+		}
+		listeners = append(listeners, responder)
+
+		go func() {
+			err := responder.ListenAndServe(dnsAddress)
+			mainLogger.Info().Str("reason", err.Error()).Msg("DNS responder stopped listening")
+		}()
+
+		// the game hosts now resolve to the proxy, so it has to serve them directly
+		for _, gameAddress := range viper.GetStringSlice("dns_game_listen_addrs") {
+			listeners = append(listeners, listenTransparent(swProxy, httpProxy, gameAddress))
+		}
+	}
+
 	// process api events
-	go sendCommandsToProxyManager(pm, apiEvents)
+	dispatcherStopped := make(chan struct{})
+	go func() {
+		sendCommandsToProxyManager(pm, apiEvents)
+		close(dispatcherStopped)
+	}()
+
+	// apply configuration changes at runtime
+	watchConfiguration(swProxy, pm, evaluator)
 
 	// Setting up signal capturing
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
-	// Waiting for SIGINT (pkill -2)
+	// Waiting for SIGINT (pkill -2) or SIGTERM
 	<-stop
 
-	// shutdown communitcation
-	pm.Shutdown()
-	close(apiEvents)
-
 	mainLogger.Info().Msg("Shutting down proxy...")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("shutdown_timeout"))
 	defer cancel()
+
+	// stop accepting connections
+	for _, listener := range listeners {
+		if err := listener.Close(); err != nil {
+			mainLogger.Warn().Err(err).Msg("Failed to close listener")
+		}
+	}
 	if err := server.Shutdown(ctx); err != nil {
-		mainLogger.Panic().Err(err).Msg("")
+		mainLogger.Warn().Err(err).Msg("Failed to wait for idle proxy connections")
+	}
+
+	// drain in-flight exchanges, this closes apiEvents
+	if err := swProxy.Shutdown(ctx); err != nil {
+		mainLogger.Warn().Err(err).Msg("Failed to drain in-flight exchanges")
+	}
+
+	// deliver all remaining events
+	select {
+	case <-dispatcherStopped:
+	case <-ctx.Done():
+		mainLogger.Warn().Msg("Failed to deliver all remaining api events")
+	}
+
+	// notify consumers and stop the proxy api
+	if err := pm.Shutdown(ctx); err != nil {
+		mainLogger.Warn().Err(err).Msg("Failed to shut down the proxy api gracefully")
+	}
+
+	if eventStore != nil {
+		if err := eventStore.Close(); err != nil {
+			mainLogger.Warn().Err(err).Msg("Failed to close the store")
+		}
 	}
 
 	mainLogger.Info().Msg("Proxy shut down")
 }
 
+func proxyApiConfiguration() pmanager.ProxyApiConfiguration {
+	mainLogger := log.With().Str("module", "main").Logger()
+
+	var configuration pmanager.ProxyApiConfiguration
+	var err error
+	if configuration.Tokens, err = readApiTokens(); err != nil {
+		mainLogger.Fatal().Err(err).Msg("Invalid proxy API token")
+	}
+
+	clientCa := viper.GetString("proxyapi_client_ca")
+	if !viper.GetBool("proxyapi_tls") && !viper.GetBool("proxyapi_consumer_tls") {
+		if clientCa != "" {
+			mainLogger.Fatal().Msg("Client certificates for the proxy API require TLS")
+		}
+		return configuration
+	}
+
+	rootCa := swproxy.LoadRootCA(viper.GetString("certificate_directory"))
+	if rootCa.Leaf, err = x509.ParseCertificate(rootCa.Certificate[0]); err != nil {
+		mainLogger.Fatal().Err(err).Msg("Failed to parse the proxy CA")
+	}
+
+	var certificate tls.Certificate
+	if certFile, keyFile := viper.GetString("proxyapi_tls_cert"), viper.GetString("proxyapi_tls_key"); certFile != "" || keyFile != "" {
+		certificate, err = tls.LoadX509KeyPair(certFile, keyFile)
+	} else {
+		certificate, err = swproxy.IssueCertificate(rootCa, viper.GetStringSlice("proxyapi_tls_hosts"))
+	}
+	if err != nil {
+		mainLogger.Fatal().Err(err).Msg("Failed to load the proxy API certificate")
+	}
+
+	if viper.GetBool("proxyapi_tls") {
+		configuration.TLSConfig = &tls.Config{Certificates: []tls.Certificate{certificate}}
+
+		switch clientCa {
+		case "":
+		case "proxy":
+			configuration.TLSConfig.ClientCAs = x509.NewCertPool()
+			configuration.TLSConfig.ClientCAs.AddCert(rootCa.Leaf)
+			configuration.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		default:
+			clientCaPem, err := ioutil.ReadFile(clientCa)
+			if err != nil {
+				mainLogger.Fatal().Err(err).Msg("Failed to read the proxy API client CA")
+			}
+			configuration.TLSConfig.ClientCAs = x509.NewCertPool()
+			if !configuration.TLSConfig.ClientCAs.AppendCertsFromPEM(clientCaPem) {
+				mainLogger.Fatal().Msg("Proxy API client CA does not contain any certificates")
+			}
+			configuration.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
+	if viper.GetBool("proxyapi_consumer_tls") {
+		// consumers may use certificates issued by the proxy CA or by any public CA
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		rootCAs.AddCert(rootCa.Leaf)
+		configuration.ConsumerTLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			RootCAs:      rootCAs,
+		}
+	}
+
+	return configuration
+}
+
+// readProxyConfiguration reads the configuration of the proxy, it is called again whenever the configuration changes
+func readProxyConfiguration() (swproxy.ProxyConfiguration, error) {
+	configuration := swproxy.ProxyConfiguration{
+		CertificateDirectory: viper.GetString("certificate_directory"),
+		InterceptHttps:       viper.GetBool("intercept_https"),
+		ForceHttpDowngrade:   viper.GetBool("force_http_downgrade"),
+		Verbose:              viper.GetBool("verbose"),
+		GameHostsOnly:        viper.GetBool("game_hosts_only"),
+	}
+
+	for _, rule := range viper.GetStringSlice("upstream_proxy") {
+		upstreamProxy, err := swproxy.ParseUpstreamProxyRule(rule)
+		if err != nil {
+			return configuration, fmt.Errorf("invalid upstream proxy rule %q: %w", rule, err)
+		}
+		configuration.UpstreamProxies = append(configuration.UpstreamProxies, upstreamProxy)
+	}
+
+	for _, network := range viper.GetStringSlice("allowed_networks") {
+		allowedNetwork, err := swproxy.ParseAllowedNetwork(network)
+		if err != nil {
+			return configuration, fmt.Errorf("invalid allowed network: %w", err)
+		}
+		configuration.AllowedNetworks = append(configuration.AllowedNetworks, allowedNetwork)
+	}
+
+	if proxyAuth := viper.GetString("proxy_auth"); proxyAuth != "" {
+		credentials := strings.SplitN(proxyAuth, ":", 2)
+		if len(credentials) != 2 {
+			return configuration, errors.New("proxy credentials must be in the form <username>:<password>")
+		}
+		configuration.ProxyUsername, configuration.ProxyPassword = credentials[0], credentials[1]
+	}
+
+	return configuration, nil
+}
+
+func readRuneRules() ([]runeeval.Rule, error) {
+	var rules []runeeval.Rule
+	for _, rule := range viper.GetStringSlice("rune_rules") {
+		runeRule, err := runeeval.ParseRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rune rule %q: %w", rule, err)
+		}
+		rules = append(rules, runeRule)
+	}
+	return rules, nil
+}
+
+func loadMonsterData(file string) {
+	mainLogger := log.With().Str("module", "main").Logger()
+
+	f, err := os.Open(file)
+	if err != nil {
+		mainLogger.Fatal().Err(err).Msg("Failed to open the monster master data")
+	}
+	defer f.Close()
+
+	monsterData, err := gamemodels.LoadMonsterData(f)
+	if err != nil {
+		mainLogger.Fatal().Err(err).Msg("Failed to read the monster master data")
+	}
+	gamemodels.SetMonsterData(monsterData)
+
+	mainLogger.Info().
+		Str("version", monsterData.Version).
+		Int("families", len(monsterData.Families)).
+		Msg("Loaded monster master data")
+}
+
+func readApiTokens() ([]pmanager.ApiToken, error) {
+	var tokens []pmanager.ApiToken
+	for _, token := range viper.GetStringSlice("proxyapi_tokens") {
+		apiToken, err := pmanager.ParseApiToken(token)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, apiToken)
+	}
+	return tokens, nil
+}
+
+func listenTransparent(swProxy *swproxy.Proxy, httpProxy http.Handler, address string) net.Listener {
+	mainLogger := log.With().Str("module", "main").Logger()
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		mainLogger.Fatal().Err(err).Str("address", address).Msg("Failed to create transparent proxy listener")
+	}
+
+	mainLogger.Info().
+		Str("transparentAddress", address).
+		Msgf("Transparent proxy listening to %s", address)
+
+	go func() {
+		err := swProxy.ServeTransparent(listener, httpProxy)
+		mainLogger.Info().Str("reason", err.Error()).Msg("Transparent proxy stopped listening")
+	}()
+
+	return listener
+}
+
+// detectOutboundIp returns the local IP address that is used to reach the given address
+func detectOutboundIp(address string) net.IP {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to detect the IP address of the proxy")
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP
+}
+
 func sendCommandsToProxyManager(pm *pmanager.ProxyManager, ev chan events.ApiEventMsg) {
 	for apiEvent := range ev {
+		// error events already carry their command
+		if apiEvent.Command != "" {
+			pm.Publish(apiEvent.Command, apiEvent)
+			continue
+		}
+
 		requestContent := map[string]interface{}{}
 		if err := json.Unmarshal([]byte(apiEvent.Request), &requestContent); err != nil {
 			log.Error().Err(err).Msg("Error while deserializing API request")
+			publishRequestDecodeError(pm, apiEvent, err)
 			continue
 		}
 		command, ok := requestContent["command"].(string)
 		if !ok {
 			log.Error().Str("request", apiEvent.Request).Msg("Failed to extract command from request")
+			publishRequestDecodeError(pm, apiEvent, errors.New("request has no command"))
 			continue
 		}
 
@@ -124,3 +587,9 @@ func sendCommandsToProxyManager(pm *pmanager.ProxyManager, ev chan events.ApiEve
 		pm.Publish(command, apiEvent)
 	}
 }
+
+func publishRequestDecodeError(pm *pmanager.ProxyManager, apiEvent events.ApiEventMsg, err error) {
+	apiEvent.Command = events.ErrorCommand(events.StageRequestDecode)
+	apiEvent.Metadata.Error = err.Error()
+	pm.Publish(apiEvent.Command, apiEvent)
+}
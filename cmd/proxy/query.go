@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/swarpf/proxy/pkg/store"
+)
+
+// runQuery implements `proxy query [--store <file>] <sql>`, which runs a SQL query against the store and
+// prints the result as table. It returns the exit code of the command.
+func runQuery(args []string) int {
+	flags := pflag.NewFlagSet("query", pflag.ExitOnError)
+	storeFile := flags.String("store", os.Getenv("SWARPF_PROXY_STORE"), "SQLite database written by the proxy")
+	timeout := flags.Duration("timeout", 30*time.Second, "Maximum duration of the query")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s query [flags] <sql>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if *storeFile == "" || flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	if _, err := os.Stat(*storeFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open the store: %v\n", err)
+		return 1
+	}
+	s, err := store.OpenReadOnly(*storeFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open the store: %v\n", err)
+		return 1
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	result, err := s.Query(ctx, strings.Join(flags.Args(), " "))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Query failed: %v\n", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(result.Columns, "\t"))
+	for _, row := range result.Rows {
+		values := make([]string, len(row))
+		for i, value := range row {
+			if value == nil {
+				values[i] = "NULL"
+			} else {
+				values[i] = fmt.Sprint(value)
+			}
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	w.Flush()
+	return 0
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+
+	"github.com/swarpf/proxy/pkg/pmanager"
+	"github.com/swarpf/proxy/pkg/runeeval"
+	"github.com/swarpf/proxy/pkg/swproxy"
+)
+
+// restartKeys are the settings that are only read on startup. Changes to them are rejected at runtime.
+var restartKeys = []string{
+	"config",
+	"proxy_listen_addr",
+	"transparent_listen_addr",
+	"socks_listen_addr",
+	"dns_listen_addr",
+	"dns_upstream_resolver",
+	"dns_proxy_ip",
+	"dns_game_listen_addrs",
+	"proxyapi_listen_addr",
+	"proxyapi_tls",
+	"proxyapi_tls_cert",
+	"proxyapi_tls_key",
+	"proxyapi_tls_hosts",
+	"proxyapi_client_ca",
+	"proxyapi_consumer_tls",
+	"intercept_https",
+	"certificate_directory",
+	"log_pretty_print",
+	"account_state",
+	"swex_export_dir",
+	"swex_export_normalize",
+	"monster_data",
+	"rune_evaluation",
+	"derived_events",
+	"run_log_csv",
+	"run_stats_csv",
+	"store",
+	"account_diff",
+	"schema_drift",
+	"schema_drift_min_observations",
+}
+
+type configurationReloader struct {
+	log     zerolog.Logger
+	swProxy *swproxy.Proxy
+	pm      *pmanager.ProxyManager
+	// evaluator is nil if rune evaluation is disabled
+	evaluator *runeeval.Evaluator
+
+	restartValues map[string]string
+}
+
+// watchConfiguration applies changes to the configuration file to the running proxy. The file is watched for
+// changes and reloaded on SIGHUP. Both are handled by the same goroutine, so a reload always reads and applies
+// the configuration file without another reload interleaving.
+func watchConfiguration(swProxy *swproxy.Proxy, pm *pmanager.ProxyManager, evaluator *runeeval.Evaluator) {
+	r := &configurationReloader{
+		log:           log.With().Str("module", "config").Logger(),
+		swProxy:       swProxy,
+		pm:            pm,
+		evaluator:     evaluator,
+		restartValues: map[string]string{},
+	}
+	for _, key := range restartKeys {
+		r.restartValues[key] = fmt.Sprint(viper.Get(key))
+	}
+
+	configFile := viper.ConfigFileUsed()
+
+	var fileEvents <-chan fsnotify.Event
+	var fileErrors <-chan error
+	if configFile != "" {
+		watcher, err := watchConfigFile(configFile)
+		if err != nil {
+			r.log.Error().Err(err).Str("config", configFile).Msg("Failed to watch configuration file for changes")
+		} else {
+			fileEvents, fileErrors = watcher.Events, watcher.Errors
+			r.log.Info().Str("config", configFile).Msg("Watching configuration file for changes")
+		}
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-hup:
+				if configFile == "" {
+					r.log.Warn().Msg("Received SIGHUP, but no configuration file is set")
+					continue
+				}
+				r.reloadFile(configFile, "received SIGHUP")
+			case ev := <-fileEvents:
+				// editors often replace the file instead of writing to it
+				if filepath.Clean(ev.Name) == filepath.Clean(configFile) && ev.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					r.reloadFile(configFile, "configuration file changed")
+				}
+			case err := <-fileErrors:
+				r.log.Warn().Err(err).Str("config", configFile).Msg("Failed to watch configuration file")
+			}
+		}
+	}()
+}
+
+// watchConfigFile watches the directory of the configuration file, so replacing the file is noticed as well
+func watchConfigFile(configFile string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(configFile)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+func (r *configurationReloader) reloadFile(configFile, reason string) {
+	if err := viper.ReadInConfig(); err != nil {
+		r.log.Error().Err(err).Str("config", configFile).Msg("Failed to read configuration file")
+		return
+	}
+	r.reload(reason)
+}
+
+func (r *configurationReloader) reload(reason string) {
+	r.log.Info().Str("reason", reason).Msg("Reloading configuration")
+
+	for _, key := range restartKeys {
+		if value := fmt.Sprint(viper.Get(key)); value != r.restartValues[key] {
+			r.log.Warn().
+				Str("key", key).
+				Str("value", value).
+				Str("current", r.restartValues[key]).
+				Msg("Rejected configuration change that requires a restart")
+		}
+	}
+
+	// validate everything before applying anything
+	proxyConfiguration, err := readProxyConfiguration()
+	if err != nil {
+		r.log.Error().Err(err).Msg("Rejected invalid proxy configuration")
+		return
+	}
+	tokens, err := readApiTokens()
+	if err != nil {
+		r.log.Error().Err(err).Msg("Rejected invalid proxy API tokens")
+		return
+	}
+	runeRules, err := readRuneRules()
+	if err != nil {
+		r.log.Error().Err(err).Msg("Rejected invalid rune rules")
+		return
+	}
+
+	applyLogLevel()
+
+	if err := r.swProxy.UpdateConfiguration(proxyConfiguration); err != nil {
+		r.log.Error().Err(err).Msg("Failed to apply proxy configuration")
+	}
+	r.pm.UpdateTokens(tokens)
+	if r.evaluator != nil {
+		r.evaluator.SetRules(runeRules)
+	}
+}
+
+func applyLogLevel() {
+	if viper.GetBool("verbose") {
+		zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	} else {
+		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	}
+}
@@ -5,13 +5,16 @@ go 1.14
 require (
 	github.com/cheapRoc/grpc-zerolog v0.0.0-20180425150930-27ca9d023ead
 	github.com/elazarl/goproxy v0.0.0-20200426045556-49ad98f6dac1
+	github.com/fsnotify/fsnotify v1.4.7
 	github.com/go-resty/resty/v2 v2.3.0 // indirect
 	github.com/golang/protobuf v1.4.2
 	github.com/rs/zerolog v1.19.0
 	github.com/spf13/afero v1.2.2
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.7.1
+	golang.org/x/net v0.0.0-20210226172049-e18ecbb05110
 	google.golang.org/grpc v1.29.1
 	google.golang.org/grpc/cmd/protoc-gen-go-grpc v0.0.0-20200617041141-9a465503579e // indirect
 	google.golang.org/protobuf v1.23.0
+	modernc.org/sqlite v1.10.8
 )
@@ -0,0 +1,138 @@
+package accountdiff
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// Snapshot is the account of a wizard as sent in a GetWizardInfo or HubUserLogin response
+type Snapshot struct {
+	Command    string                `json:"command"`
+	TakenAt    time.Time             `json:"taken_at"`
+	WizardInfo gamemodels.WizardInfo `json:"wizard_info"`
+	// Runes and Units are only sent on login, they are carried over from the previous snapshot for GetWizardInfo.
+	// Both are nil if the wizard didn't log in yet. The runes of a unit are part of Runes and not of the unit.
+	Runes map[int]gamemodels.Rune `json:"runes"`
+	Units map[int]gamemodels.Unit `json:"units"`
+}
+
+// HasInventory returns true if the runes and units of the wizard are known
+func (s Snapshot) HasInventory() bool {
+	return s.Runes != nil && s.Units != nil
+}
+
+// RuneChange is a rune that was changed between two snapshots, e.g. upgraded, reappraised or equipped
+type RuneChange struct {
+	Before gamemodels.Rune `json:"before"`
+	After  gamemodels.Rune `json:"after"`
+	// Fields are the json names of the changed fields of the rune
+	Fields []string `json:"fields"`
+}
+
+// Diff contains the changes of an account between two snapshots
+type Diff struct {
+	WizardId        int       `json:"wizard_id"`
+	PreviousCommand string    `json:"previous_command"`
+	PreviousTakenAt time.Time `json:"previous_taken_at"`
+	Command         string    `json:"command"`
+	TakenAt         time.Time `json:"taken_at"`
+
+	ManaDelta    int `json:"mana_delta"`
+	CrystalDelta int `json:"crystal_delta"`
+
+	// InventoryCompared is false if the runes and units of one of the snapshots are unknown, or if they
+	// weren't sent again by the game since the previous snapshot
+	InventoryCompared bool              `json:"inventory_compared"`
+	RunesAdded        []gamemodels.Rune `json:"runes_added"`
+	RunesRemoved      []gamemodels.Rune `json:"runes_removed"`
+	RunesChanged      []RuneChange      `json:"runes_changed"`
+	UnitsGained       []gamemodels.Unit `json:"units_gained"`
+	UnitsLost         []gamemodels.Unit `json:"units_lost"`
+}
+
+// Empty returns true if nothing changed between the snapshots
+func (d Diff) Empty() bool {
+	return d.ManaDelta == 0 && d.CrystalDelta == 0 &&
+		len(d.RunesAdded) == 0 && len(d.RunesRemoved) == 0 && len(d.RunesChanged) == 0 &&
+		len(d.UnitsGained) == 0 && len(d.UnitsLost) == 0
+}
+
+// Compare returns the changes from previous to current. Runes and units are only compared if current was
+// taken from a login and the inventory of both snapshots is known.
+func Compare(previous, current Snapshot) Diff {
+	diff := Diff{
+		WizardId:        current.WizardInfo.WizardId,
+		PreviousCommand: previous.Command,
+		PreviousTakenAt: previous.TakenAt,
+		Command:         current.Command,
+		TakenAt:         current.TakenAt,
+		ManaDelta:       current.WizardInfo.WizardMana - previous.WizardInfo.WizardMana,
+		CrystalDelta:    current.WizardInfo.WizardCrystal - previous.WizardInfo.WizardCrystal,
+	}
+
+	if current.Command != "HubUserLogin" || !previous.HasInventory() || !current.HasInventory() {
+		return diff
+	}
+	diff.InventoryCompared = true
+
+	for _, id := range sortedKeys(current.Runes) {
+		after := current.Runes[id]
+		before, ok := previous.Runes[id]
+		if !ok {
+			diff.RunesAdded = append(diff.RunesAdded, after)
+		} else if fields := changedFields(before, after); len(fields) > 0 {
+			diff.RunesChanged = append(diff.RunesChanged, RuneChange{Before: before, After: after, Fields: fields})
+		}
+	}
+	for _, id := range sortedKeys(previous.Runes) {
+		if _, ok := current.Runes[id]; !ok {
+			diff.RunesRemoved = append(diff.RunesRemoved, previous.Runes[id])
+		}
+	}
+
+	for _, id := range sortedKeys(current.Units) {
+		if _, ok := previous.Units[id]; !ok {
+			diff.UnitsGained = append(diff.UnitsGained, current.Units[id])
+		}
+	}
+	for _, id := range sortedKeys(previous.Units) {
+		if _, ok := current.Units[id]; !ok {
+			diff.UnitsLost = append(diff.UnitsLost, previous.Units[id])
+		}
+	}
+
+	return diff
+}
+
+// changedFields returns the json names of the fields that differ between two structs of the same type. Empty
+// and nil slices are considered equal.
+func changedFields(before, after interface{}) []string {
+	b, a := reflect.ValueOf(before), reflect.ValueOf(after)
+
+	var fields []string
+	for i := 0; i < b.NumField(); i++ {
+		bf, af := b.Field(i), a.Field(i)
+		if bf.Kind() == reflect.Slice && bf.Len() == 0 && af.Len() == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(bf.Interface(), af.Interface()) {
+			fields = append(fields, strings.Split(b.Type().Field(i).Tag.Get("json"), ",")[0])
+		}
+	}
+	return fields
+}
+
+// sortedKeys returns the keys of a map[int]T in ascending order
+func sortedKeys(m interface{}) []int {
+	v := reflect.ValueOf(m)
+	keys := make([]int, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		keys = append(keys, int(key.Int()))
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,137 @@
+package accountdiff
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+var takenAt = time.Date(2020, 10, 8, 12, 0, 0, 0, time.UTC)
+
+func snapshot(command string, mana, crystal int, runes []gamemodels.Rune, units []gamemodels.Unit) Snapshot {
+	s := Snapshot{
+		Command:    command,
+		TakenAt:    takenAt,
+		WizardInfo: gamemodels.WizardInfo{WizardId: 1, WizardMana: mana, WizardCrystal: crystal},
+	}
+	if runes != nil {
+		s.Runes = map[int]gamemodels.Rune{}
+		for _, r := range runes {
+			s.Runes[r.RuneId] = r
+		}
+	}
+	if units != nil {
+		s.Units = map[int]gamemodels.Unit{}
+		for _, unit := range units {
+			s.Units[unit.UnitId] = unit
+		}
+	}
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	innate := &gamemodels.RuneStat{EffectType: gamemodels.Spd, EffectValue: 4}
+	previous := snapshot("HubUserLogin", 1000, 50, []gamemodels.Rune{
+		{RuneId: 1, Level: 6, InnateStat: innate},
+		{RuneId: 2, Substats: []gamemodels.RuneStat{}},
+		{RuneId: 3, OccupiedId: 100, InnateStat: innate},
+		{RuneId: 4},
+	}, []gamemodels.Unit{{UnitId: 100}, {UnitId: 101}})
+	current := snapshot("HubUserLogin", 1500, 20, []gamemodels.Rune{
+		// upgraded
+		{RuneId: 1, Level: 9, InnateStat: innate,
+			Substats: []gamemodels.RuneStat{{EffectType: gamemodels.Cr, EffectValue: 5}}},
+		// empty and nil substats are equal
+		{RuneId: 2},
+		// unequipped, the innate stat is an equal copy
+		{RuneId: 3, InnateStat: &gamemodels.RuneStat{EffectType: gamemodels.Spd, EffectValue: 4}},
+		{RuneId: 5},
+		{RuneId: 6},
+	}, []gamemodels.Unit{{UnitId: 100}, {UnitId: 102}})
+
+	diff := Compare(previous, current)
+	if !diff.InventoryCompared || diff.Empty() || diff.ManaDelta != 500 || diff.CrystalDelta != -30 {
+		t.Errorf("diff = %+v", diff)
+	}
+
+	got := map[string]string{
+		"runes added":   fmt.Sprint(runeIds(diff.RunesAdded)),
+		"runes removed": fmt.Sprint(runeIds(diff.RunesRemoved)),
+		"units gained":  fmt.Sprint(unitIds(diff.UnitsGained)),
+		"units lost":    fmt.Sprint(unitIds(diff.UnitsLost)),
+	}
+	want := map[string]string{
+		"runes added":   "[5 6]",
+		"runes removed": "[4]",
+		"units gained":  "[102]",
+		"units lost":    "[101]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diff = %v, want %v", got, want)
+	}
+
+	changed := map[int][]string{}
+	for _, change := range diff.RunesChanged {
+		if change.Before.RuneId != change.After.RuneId {
+			t.Errorf("change of rune %d has rune %d after", change.Before.RuneId, change.After.RuneId)
+		}
+		changed[change.After.RuneId] = change.Fields
+	}
+	wantChanged := map[int][]string{
+		1: {"upgrade_curr", "sec_eff"},
+		3: {"occupied_id"},
+	}
+	if !reflect.DeepEqual(changed, wantChanged) {
+		t.Errorf("changed runes = %v, want %v", changed, wantChanged)
+	}
+}
+
+func TestCompareWithoutInventory(t *testing.T) {
+	runes := []gamemodels.Rune{{RuneId: 1}}
+	units := []gamemodels.Unit{{UnitId: 100}}
+
+	tests := []struct {
+		name     string
+		previous Snapshot
+		current  Snapshot
+	}{
+		// the inventory of GetWizardInfo snapshots is carried over and was not sent by the game
+		{"wizard info", snapshot("HubUserLogin", 1000, 50, runes, units), snapshot("GetWizardInfo", 900, 50, nil, []gamemodels.Unit{})},
+		{"first login", snapshot("GetWizardInfo", 1000, 50, nil, nil), snapshot("HubUserLogin", 900, 50, runes, units)},
+		{"unknown units", snapshot("HubUserLogin", 1000, 50, runes, nil), snapshot("HubUserLogin", 900, 50, runes, units)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := Compare(tt.previous, tt.current)
+			if diff.InventoryCompared || len(diff.RunesAdded) != 0 || len(diff.UnitsLost) != 0 || diff.ManaDelta != -100 {
+				t.Errorf("diff = %+v, want only the mana delta", diff)
+			}
+		})
+	}
+}
+
+func TestDiffEmpty(t *testing.T) {
+	s := snapshot("HubUserLogin", 1000, 50, []gamemodels.Rune{{RuneId: 1}}, []gamemodels.Unit{{UnitId: 100}})
+	if diff := Compare(s, s); !diff.Empty() || !diff.InventoryCompared {
+		t.Errorf("diff of equal snapshots = %+v, want an empty diff", diff)
+	}
+}
+
+func runeIds(runes []gamemodels.Rune) []int {
+	var ids []int
+	for _, r := range runes {
+		ids = append(ids, r.RuneId)
+	}
+	return ids
+}
+
+func unitIds(units []gamemodels.Unit) []int {
+	var ids []int
+	for _, unit := range units {
+		ids = append(ids, unit.UnitId)
+	}
+	return ids
+}
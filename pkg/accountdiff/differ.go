@@ -0,0 +1,166 @@
+package accountdiff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// AccountDiff is published with the Diff of two consecutive snapshots of an account as response, along with
+// the field `source_command`. Diffs without any changes are not published.
+const AccountDiff = events.DerivedNamespace + ".AccountDiff"
+
+// SnapshotStore persists the latest snapshot of every wizard, so accounts can be compared across restarts of
+// the proxy
+type SnapshotStore interface {
+	// LoadSnapshot returns false if there is no snapshot of the wizard
+	LoadSnapshot(wizardId int) (Snapshot, bool, error)
+	SaveSnapshot(snapshot Snapshot) error
+}
+
+// Differ compares consecutive GetWizardInfo and HubUserLogin snapshots of an account and publishes their
+// changes as AccountDiff events.
+//
+// Differ is an in-process plugin, see pmanager.Plugin.
+type Differ struct {
+	log       zerolog.Logger
+	publisher events.Publisher
+	snapshots SnapshotStore
+
+	// latest snapshot by wizard, it is only used by the plugin goroutine
+	latest map[int]Snapshot
+}
+
+// New creates a differ, snapshots is optional
+func New(publisher events.Publisher, snapshots SnapshotStore) *Differ {
+	return &Differ{
+		log:       log.With().Timestamp().Str("log_type", "module").Str("module", "AccountDiff").Logger(),
+		publisher: publisher,
+		snapshots: snapshots,
+		latest:    map[int]Snapshot{},
+	}
+}
+
+func (d *Differ) Name() string {
+	return "accountdiff"
+}
+
+func (d *Differ) Commands() []string {
+	return []string{"GetWizardInfo", "HubUserLogin"}
+}
+
+type snapshotResponse struct {
+	gamemodels.ApiResponse
+	WizardInfo *gamemodels.WizardInfo `json:"wizard_info"`
+	UnitList   []gamemodels.Unit      `json:"unit_list"`
+	Runes      []gamemodels.Rune      `json:"runes"`
+}
+
+func (d *Differ) Handle(_ context.Context, ev events.ApiEventMsg) error {
+	var response snapshotResponse
+	if err := json.Unmarshal([]byte(ev.Response), &response); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", ev.Command, err)
+	}
+	if response.RetCode != 0 || response.WizardInfo == nil {
+		return nil
+	}
+
+	current := Snapshot{Command: ev.Command, TakenAt: eventTime(ev), WizardInfo: *response.WizardInfo}
+	previous, ok := d.latestSnapshot(current.WizardInfo.WizardId)
+
+	if ev.Command == "HubUserLogin" {
+		current.Runes, current.Units = map[int]gamemodels.Rune{}, map[int]gamemodels.Unit{}
+		for _, r := range response.Runes {
+			current.Runes[r.RuneId] = r
+		}
+		for _, unit := range response.UnitList {
+			for _, r := range unit.Runes {
+				current.Runes[r.RuneId] = r
+			}
+			unit.Runes = nil
+			current.Units[unit.UnitId] = unit
+		}
+	} else if ok {
+		current.Runes, current.Units = previous.Runes, previous.Units
+	}
+
+	d.latest[current.WizardInfo.WizardId] = current
+	if d.snapshots != nil {
+		if err := d.snapshots.SaveSnapshot(current); err != nil {
+			d.log.Error().Err(err).Int("wizardId", current.WizardInfo.WizardId).Msg("Failed to save snapshot")
+		}
+	}
+
+	if !ok {
+		return nil
+	}
+
+	diff := Compare(previous, current)
+	if diff.Empty() {
+		return nil
+	}
+	d.publish(ev, diff)
+
+	return nil
+}
+
+// latestSnapshot returns the latest snapshot of a wizard, from the snapshot store if there is none in memory
+func (d *Differ) latestSnapshot(wizardId int) (Snapshot, bool) {
+	if snapshot, ok := d.latest[wizardId]; ok {
+		return snapshot, true
+	}
+	if d.snapshots == nil {
+		return Snapshot{}, false
+	}
+
+	snapshot, ok, err := d.snapshots.LoadSnapshot(wizardId)
+	if err != nil {
+		d.log.Error().Err(err).Int("wizardId", wizardId).Msg("Failed to load snapshot")
+		return Snapshot{}, false
+	}
+	return snapshot, ok
+}
+
+func (d *Differ) publish(ev events.ApiEventMsg, diff Diff) {
+	data, err := json.Marshal(struct {
+		SourceCommand string `json:"source_command"`
+		Diff
+	}{ev.Command, diff})
+	if err != nil {
+		d.log.Error().Err(err).Msg("Failed to encode account diff")
+		return
+	}
+
+	d.log.Info().
+		Int("wizardId", diff.WizardId).
+		Int("manaDelta", diff.ManaDelta).
+		Int("crystalDelta", diff.CrystalDelta).
+		Int("runesAdded", len(diff.RunesAdded)).
+		Int("runesRemoved", len(diff.RunesRemoved)).
+		Int("runesChanged", len(diff.RunesChanged)).
+		Int("unitsGained", len(diff.UnitsGained)).
+		Int("unitsLost", len(diff.UnitsLost)).
+		Msg("Account changed")
+
+	d.publisher.Publish(AccountDiff, events.ApiEventMsg{
+		Command:  AccountDiff,
+		Request:  ev.Request,
+		Response: string(data),
+		Metadata: ev.Metadata,
+	})
+}
+
+// eventTime returns the time the game client sent the request
+func eventTime(ev events.ApiEventMsg) time.Time {
+	if !ev.Metadata.RequestStart.IsZero() {
+		return ev.Metadata.RequestStart
+	}
+	return time.Now()
+}
@@ -0,0 +1,62 @@
+package accountdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelstest"
+)
+
+type memorySnapshots map[int]Snapshot
+
+func (m memorySnapshots) LoadSnapshot(wizardId int) (Snapshot, bool, error) {
+	s, ok := m[wizardId]
+	return s, ok, nil
+}
+
+func (m memorySnapshots) SaveSnapshot(snapshot Snapshot) error {
+	m[snapshot.WizardInfo.WizardId] = snapshot
+	return nil
+}
+
+func TestDifferHandle(t *testing.T) {
+	var published []Diff
+	publisher := events.PublisherFunc(func(topic string, msg events.ApiEventMsg) {
+		var diff Diff
+		if err := json.Unmarshal([]byte(msg.Response), &diff); err != nil {
+			t.Fatal(err)
+		}
+		published = append(published, diff)
+	})
+	snapshots := memorySnapshots{}
+	d := New(publisher, snapshots)
+	wizardInfo := gamemodelstest.Event(t, "GetWizardInfo")
+	gamemodelstest.Handle(t, d, gamemodelstest.Event(t, "HubUserLogin"), wizardInfo)
+	// nothing changed
+	gamemodelstest.Handle(t, d, wizardInfo)
+	if len(published) != 1 || published[0].ManaDelta != -2000 || published[0].InventoryCompared {
+		t.Fatalf("published %+v, want the mana delta of the wizard info", published)
+	}
+
+	// the snapshot of the last session is compared with the next login
+	d = New(publisher, snapshots)
+	gamemodelstest.Handle(t, d, gamemodelstest.Event(t, "HubUserLogin.after"))
+	if len(published) != 2 {
+		t.Fatalf("published %d diffs, want 2", len(published))
+	}
+	diff := published[1]
+	if !diff.InventoryCompared || len(diff.RunesAdded) != 2 || len(diff.RunesRemoved) != 1 || len(diff.RunesChanged) != 3 ||
+		len(diff.UnitsGained) != 3 || len(diff.UnitsLost) != 1 {
+		t.Fatalf("diff = %+v, want 2 runes added, 1 removed and 3 changed, 3 units gained and 1 lost", diff)
+	}
+	if diff.RunesRemoved[0].RuneId != 30000000002 || diff.UnitsLost[0].UnitId != 9000000002 {
+		t.Errorf("diff = %+v, want the sold rune 30000000002 and the consumed unit 9000000002", diff)
+	}
+	for _, change := range diff.RunesChanged {
+		if fmt.Sprint(change.Fields) != "[occupied_type occupied_id]" {
+			t.Errorf("rune %d changed %v, want it moved", change.After.RuneId, change.Fields)
+		}
+	}
+}
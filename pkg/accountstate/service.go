@@ -0,0 +1,105 @@
+package accountstate
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/swarpf/proxy/pkg/structuredevent"
+)
+
+// The account state service is served next to the proxy api, so plugins can query the current state instead
+// of rebuilding it from the events. Like the structured consumer service it only uses well-known protobuf types:
+//
+//	service AccountState {
+//	  rpc GetAccountState(google.protobuf.Empty) returns (google.protobuf.Struct);
+//	}
+//
+// The struct is the json encoding of State.
+const (
+	ServiceName = "swarpf.proxyapi.AccountState"
+	MethodName  = "GetAccountState"
+	FullMethod  = "/" + ServiceName + "/" + MethodName
+)
+
+// Server is implemented by Tracker
+type Server interface {
+	GetAccountState(ctx context.Context, in *emptypb.Empty) (*structpb.Struct, error)
+}
+
+// RegisterServer registers the account state service at s
+func RegisterServer(s *grpc.Server, srv Server) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: MethodName,
+			Handler:    handleGetAccountState,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func handleGetAccountState(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Server).GetAccountState(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FullMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Server).GetAccountState(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func (t *Tracker) GetAccountState(context.Context, *emptypb.Empty) (*structpb.Struct, error) {
+	state := t.State()
+	if !state.Seeded() {
+		return nil, status.Error(codes.Unavailable, "the account state is not known until the player logs in")
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return structuredevent.ToValue(decoded).GetStructValue(), nil
+}
+
+// Client queries the account state from the proxy
+type Client struct {
+	cc *grpc.ClientConn
+}
+
+func NewClient(cc *grpc.ClientConn) *Client {
+	return &Client{cc: cc}
+}
+
+func (c *Client) GetAccountState(ctx context.Context, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, FullMethod, &emptypb.Empty{}, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package accountstate
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// State is a snapshot of the account of the player
+type State struct {
+	WizardInfo gamemodels.WizardInfo `json:"wizard_info"`
+	// Units by their unit_id, without their equipped runes and artifacts
+	Units map[int]gamemodels.Unit `json:"units"`
+	// Runes by their rune_id, equipped runes have the unit_id of their unit as occupied_id
+	Runes map[int]gamemodels.Rune `json:"runes"`
+	// Artifacts by their rid, equipped artifacts have the unit_id of their unit as occupied_id
+	Artifacts map[int]gamemodels.Artifact `json:"artifacts"`
+	// Inventory contains the quantity of all items by InventoryKey
+	Inventory map[string]int `json:"inventory"`
+	// Buildings by their building_id
+	Buildings map[int]gamemodels.Building `json:"buildings"`
+	// UpdatedAt is the time of the last event that changed the state
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newState() *State {
+	return &State{
+		Units:     map[int]gamemodels.Unit{},
+		Runes:     map[int]gamemodels.Rune{},
+		Artifacts: map[int]gamemodels.Artifact{},
+		Inventory: map[string]int{},
+		Buildings: map[int]gamemodels.Building{},
+	}
+}
+
+// InventoryKey is the key of an item in State.Inventory
+func InventoryKey(itemType gamemodels.GameItem, itemId int) string {
+	return strconv.Itoa(int(itemType)) + ":" + strconv.Itoa(itemId)
+}
+
+// Seeded returns false until the state was seeded from the login of the player
+func (s State) Seeded() bool {
+	return s.WizardInfo.WizardId != 0
+}
+
+// copy returns a deep enough copy of the state, game objects are replaced on change and never modified in place
+func (s *State) copy() State {
+	c := *s
+
+	c.Units = make(map[int]gamemodels.Unit, len(s.Units))
+	for id, unit := range s.Units {
+		c.Units[id] = unit
+	}
+	c.Runes = make(map[int]gamemodels.Rune, len(s.Runes))
+	for id, r := range s.Runes {
+		c.Runes[id] = r
+	}
+	c.Artifacts = make(map[int]gamemodels.Artifact, len(s.Artifacts))
+	for id, artifact := range s.Artifacts {
+		c.Artifacts[id] = artifact
+	}
+
+	c.Inventory = make(map[string]int, len(s.Inventory))
+	for key, quantity := range s.Inventory {
+		c.Inventory[key] = quantity
+	}
+
+	c.Buildings = make(map[int]gamemodels.Building, len(s.Buildings))
+	for id, building := range s.Buildings {
+		c.Buildings[id] = building
+	}
+
+	return c
+}
@@ -0,0 +1,342 @@
+package accountstate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// Tracker maintains the state of the account from the api events. It is seeded from HubUserLogin (or
+// GetWizardInfo for the wizard info only) and updated from every later response that contains game objects,
+// e.g. rune upgrades, summons or battle rewards.
+//
+// Tracker is an in-process plugin, see pmanager.Plugin.
+type Tracker struct {
+	log zerolog.Logger
+
+	mu    sync.RWMutex
+	state *State
+}
+
+func New() *Tracker {
+	return &Tracker{
+		log:   log.With().Timestamp().Str("log_type", "module").Str("module", "AccountState").Logger(),
+		state: newState(),
+	}
+}
+
+func (t *Tracker) Name() string {
+	return "accountstate"
+}
+
+// Commands subscribes to all game commands, game objects are part of the responses of many commands
+func (t *Tracker) Commands() []string {
+	return []string{"*"}
+}
+
+// State returns a snapshot of the account state
+func (t *Tracker) State() State {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.state.copy()
+}
+
+func (t *Tracker) Handle(_ context.Context, ev events.ApiEventMsg) error {
+	if events.Namespace(ev.Command) != "" {
+		return nil
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal([]byte(ev.Response), &response); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", ev.Command, err)
+	}
+	// failed commands did not change anything
+	if retCode, ok := response["ret_code"].(float64); ok && retCode != 0 {
+		return nil
+	}
+
+	var request map[string]interface{}
+	if err := json.Unmarshal([]byte(ev.Request), &request); err != nil {
+		return fmt.Errorf("failed to decode request of %s: %w", ev.Command, err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ev.Command == "HubUserLogin" {
+		t.state = newState()
+	}
+
+	if err := t.apply(ev.Command, request, response); err != nil {
+		return fmt.Errorf("failed to update account state from %s: %w", ev.Command, err)
+	}
+	t.state.UpdatedAt = time.Now()
+
+	if ev.Command == "HubUserLogin" {
+		t.log.Info().
+			Int("wizardId", t.state.WizardInfo.WizardId).
+			Int("units", len(t.state.Units)).
+			Int("runes", len(t.state.Runes)).
+			Int("artifacts", len(t.state.Artifacts)).
+			Msg("Seeded account state")
+	}
+
+	return nil
+}
+
+// apply updates the state with all game objects in the exchange
+func (t *Tracker) apply(command string, request, response map[string]interface{}) error {
+	if wizardInfo, ok := response["wizard_info"]; ok {
+		if err := remarshal(wizardInfo, &t.state.WizardInfo); err != nil {
+			return err
+		}
+	}
+
+	// feeding material is consumed, its runes are moved to the inventory
+	for _, material := range objectList(request["source_unit_list"]) {
+		t.removeUnit(material.Int("source_unit_id"))
+	}
+	if command == "SellRune" {
+		for _, runeId := range object(request).intList("rune_id_list") {
+			delete(t.state.Runes, runeId)
+		}
+	}
+
+	for _, unit := range objectList(response["unit_list"]) {
+		if err := t.setUnit(unit); err != nil {
+			return err
+		}
+	}
+	if unit, ok := response["unit_info"].(map[string]interface{}); ok {
+		if err := t.setUnit(unit); err != nil {
+			return err
+		}
+	}
+	for _, r := range objectList(response["runes"]) {
+		if err := t.setRune(r); err != nil {
+			return err
+		}
+	}
+	if r, ok := response["rune"].(map[string]interface{}); ok {
+		if err := t.setRune(r); err != nil {
+			return err
+		}
+	}
+	for _, artifact := range objectList(response["artifacts"]) {
+		if err := t.setArtifact(artifact); err != nil {
+			return err
+		}
+	}
+	if artifact, ok := response["artifact"].(map[string]interface{}); ok {
+		if err := t.setArtifact(artifact); err != nil {
+			return err
+		}
+	}
+	for _, item := range objectList(response["inventory_info"]) {
+		t.setItem(item)
+	}
+
+	for _, building := range objectList(response["building_list"]) {
+		var b gamemodels.Building
+		if err := remarshal(building, &b); err != nil {
+			return err
+		}
+		t.state.Buildings[b.BuildingId] = b
+	}
+
+	for _, changed := range objectList(response["changed_item_list"]) {
+		info, ok := changed["info"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch gamemodels.GameItem(changed.Int("type")) {
+		case gamemodels.CategoryMonster:
+			err = t.setUnit(info)
+		case gamemodels.CategoryRune:
+			err = t.setRune(info)
+		default:
+			t.setItem(info)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setUnit stores the unit and its equipped runes and artifacts. Runes and artifacts that are no longer
+// equipped are only moved to the inventory if the unit was sent with its runes (or artifacts).
+func (t *Tracker) setUnit(raw object) error {
+	var unit gamemodels.Unit
+	if err := remarshal(raw, &unit); err != nil {
+		return err
+	}
+	if unit.UnitId == 0 {
+		return nil
+	}
+
+	if _, ok := raw["runes"]; ok {
+		equipped := map[int]bool{}
+		for _, r := range unit.Runes {
+			equipped[r.RuneId] = true
+			t.storeRune(r)
+		}
+		t.unequipRunes(unit.UnitId, equipped)
+	}
+	if _, ok := raw["artifacts"]; ok {
+		equipped := map[int]bool{}
+		for _, artifact := range unit.Artifacts {
+			equipped[artifact.ArtifactId] = true
+			t.storeArtifact(artifact)
+		}
+		t.unequipArtifacts(unit.UnitId, equipped)
+	}
+
+	unit.Runes, unit.Artifacts = nil, nil
+	t.state.Units[unit.UnitId] = unit
+	return nil
+}
+
+func (t *Tracker) removeUnit(unitId int) {
+	if unitId == 0 {
+		return
+	}
+
+	delete(t.state.Units, unitId)
+	t.unequipRunes(unitId, nil)
+	t.unequipArtifacts(unitId, nil)
+}
+
+func (t *Tracker) setRune(raw object) error {
+	var r gamemodels.Rune
+	if err := remarshal(raw, &r); err != nil {
+		return err
+	}
+	t.storeRune(r)
+	return nil
+}
+
+func (t *Tracker) storeRune(r gamemodels.Rune) {
+	if r.RuneId != 0 {
+		t.state.Runes[r.RuneId] = r
+	}
+}
+
+func (t *Tracker) setArtifact(raw object) error {
+	var artifact gamemodels.Artifact
+	if err := remarshal(raw, &artifact); err != nil {
+		return err
+	}
+	t.storeArtifact(artifact)
+	return nil
+}
+
+func (t *Tracker) storeArtifact(artifact gamemodels.Artifact) {
+	if artifact.ArtifactId != 0 {
+		t.state.Artifacts[artifact.ArtifactId] = artifact
+	}
+}
+
+// setItem stores the quantity of an inventory item, the game api always sends the new total
+func (t *Tracker) setItem(item object) {
+	if _, ok := item["item_quantity"]; !ok || item.Int("item_master_id") == 0 {
+		return
+	}
+
+	key := InventoryKey(gamemodels.GameItem(item.Int("item_master_type")), item.Int("item_master_id"))
+	t.state.Inventory[key] = item.Int("item_quantity")
+}
+
+// unequipRunes moves all runes that are equipped on the unit but are not in equipped to the inventory
+func (t *Tracker) unequipRunes(unitId int, equipped map[int]bool) {
+	for id, r := range t.state.Runes {
+		if r.OccupiedId == unitId && !equipped[id] {
+			r.OccupiedId = 0
+			t.state.Runes[id] = r
+		}
+	}
+}
+
+// unequipArtifacts moves all artifacts that are equipped on the unit but are not in equipped to the inventory
+func (t *Tracker) unequipArtifacts(unitId int, equipped map[int]bool) {
+	for id, artifact := range t.state.Artifacts {
+		if artifact.OccupiedId == unitId && !equipped[id] {
+			artifact.OccupiedId = 0
+			t.state.Artifacts[id] = artifact
+		}
+	}
+}
+
+// object is a decoded json object of the game api
+type object map[string]interface{}
+
+// Int returns the integer field key of the object, the game api sends numbers as json numbers or strings
+func (o object) Int(key string) int {
+	return toInt(o[key])
+}
+
+func (o object) intList(key string) []int {
+	values, _ := o[key].([]interface{})
+
+	var ints []int
+	for _, value := range values {
+		if i := toInt(value); i != 0 {
+			ints = append(ints, i)
+		}
+	}
+	return ints
+}
+
+func toInt(v interface{}) int {
+	switch v := v.(type) {
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	default:
+		return 0
+	}
+}
+
+// objectList returns the objects of a json array or of a json object keyed by e.g. the rune slot
+func objectList(v interface{}) []object {
+	var objects []object
+	switch v := v.(type) {
+	case []interface{}:
+		for _, element := range v {
+			if o, ok := element.(map[string]interface{}); ok {
+				objects = append(objects, o)
+			}
+		}
+	case map[string]interface{}:
+		for _, element := range v {
+			if o, ok := element.(map[string]interface{}); ok {
+				objects = append(objects, o)
+			}
+		}
+	}
+	return objects
+}
+
+// remarshal decodes a decoded json value into out
+func remarshal(v interface{}, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
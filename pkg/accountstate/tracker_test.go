@@ -0,0 +1,120 @@
+package accountstate
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelstest"
+)
+
+func TestTrackerSeed(t *testing.T) {
+	tracker := New()
+	gamemodelstest.Handle(t, tracker, gamemodelstest.Event(t, "HubUserLogin"))
+
+	state := tracker.State()
+	if !state.Seeded() || state.WizardInfo.WizardName != "tester" {
+		t.Fatalf("state was not seeded: %+v", state.WizardInfo)
+	}
+	if len(state.Units) != 2 || len(state.Runes) != 5 || len(state.Artifacts) != 2 {
+		t.Fatalf("state has %d units, %d runes and %d artifacts, want 2, 5 and 2",
+			len(state.Units), len(state.Runes), len(state.Artifacts))
+	}
+
+	unit := state.Units[9000000001]
+	if unit.Stars != 6 || unit.Level != 40 || unit.Runes != nil || unit.Artifacts != nil {
+		t.Errorf("unit 9000000001 = %+v, want a 6 star unit without runes and artifacts", unit)
+	}
+
+	ancient := state.Runes[30000000001]
+	if !ancient.Ancient || ancient.Stars != 6 || ancient.OccupiedId != 9000000001 {
+		t.Errorf("rune 30000000001 = %+v, want an ancient 6 star rune on unit 9000000001", ancient)
+	}
+	if innate := ancient.InnateStat; innate == nil || innate.EffectType != gamemodels.Cr {
+		t.Errorf("innate stat of rune 30000000001 = %+v, want crit rate", innate)
+	}
+	if innate := state.Runes[30000000002].InnateStat; innate != nil {
+		t.Errorf("innate stat of rune 30000000002 = %+v, want none", innate)
+	}
+	if r := state.Runes[30000000006]; r.OccupiedId != 9000000002 || r.Slot != 6 {
+		t.Errorf("slot-keyed rune 30000000006 = %+v, want it in slot 6 of unit 9000000002", r)
+	}
+	if artifact := state.Artifacts[700000002]; artifact.OccupiedId != 9000000002 || artifact.MainStat.Value != 240 {
+		t.Errorf("artifact 700000002 = %+v, want it on unit 9000000002", artifact)
+	}
+	if quantity := state.Inventory[InventoryKey(gamemodels.CategorySummonScroll, 1)]; quantity != 5 {
+		t.Errorf("inventory quantity = %d, want 5", quantity)
+	}
+}
+
+func TestTrackerUpdates(t *testing.T) {
+	tracker := New()
+	session := gamemodelstest.Session(t)
+	gamemodelstest.Handle(t, tracker, session[0])
+
+	// failed commands are ignored
+	gamemodelstest.Handle(t, tracker,
+		gamemodelstest.Failed(gamemodelstest.Event(t, "SellRune")),
+		gamemodelstest.Failed(gamemodelstest.Event(t, "UpgradeUnit")),
+	)
+	if state := tracker.State(); len(state.Runes) != 5 || len(state.Units) != 2 {
+		t.Fatalf("state has %d units and %d runes after failed commands, want 2 and 5", len(state.Units), len(state.Runes))
+	}
+
+	gamemodelstest.Handle(t, tracker, session[1:]...)
+
+	// the state after the session is the account of the next login
+	next := New()
+	gamemodelstest.Handle(t, next, gamemodelstest.Event(t, "HubUserLogin.after"))
+
+	state, want := tracker.State(), next.State()
+	if w := state.WizardInfo; w.WizardMana != want.WizardInfo.WizardMana || w.WizardCrystal != want.WizardInfo.WizardCrystal {
+		t.Errorf("wizard info = %+v, want %+v", w, want.WizardInfo)
+	}
+	if len(state.Units) != len(want.Units) {
+		t.Errorf("state has %d units, want %d", len(state.Units), len(want.Units))
+	}
+	for id := range want.Units {
+		if _, ok := state.Units[id]; !ok {
+			t.Errorf("unit %d is not in the state", id)
+		}
+	}
+	if len(state.Runes) != len(want.Runes) {
+		t.Errorf("state has %d runes, want %d", len(state.Runes), len(want.Runes))
+	}
+	for id, r := range want.Runes {
+		if got, ok := state.Runes[id]; !ok || got.OccupiedId != r.OccupiedId {
+			t.Errorf("rune %d = %+v, want it on unit %d", id, got, r.OccupiedId)
+		}
+	}
+	for id, artifact := range want.Artifacts {
+		if got, ok := state.Artifacts[id]; !ok || got.OccupiedId != artifact.OccupiedId {
+			t.Errorf("artifact %d = %+v, want it on unit %d", id, got, artifact.OccupiedId)
+		}
+	}
+
+	// the dropped scroll, the summon scrolls are spent without an inventory update
+	if quantity := state.Inventory[InventoryKey(gamemodels.CategorySummonScroll, 1)]; quantity != 6 {
+		t.Errorf("inventory quantity = %d, want 6", quantity)
+	}
+}
+
+func TestGetAccountState(t *testing.T) {
+	tracker := New()
+	if _, err := tracker.GetAccountState(context.Background(), &emptypb.Empty{}); err == nil {
+		t.Error("GetAccountState() succeeded before the login")
+	}
+
+	gamemodelstest.Handle(t, tracker, gamemodelstest.Event(t, "HubUserLogin"))
+	state, err := tracker.GetAccountState(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := state.Fields["runes"].GetStructValue().Fields["30000000001"].GetStructValue()
+	if r == nil || !r.Fields["ancient"].GetBoolValue() || r.Fields["class"].GetNumberValue() != 6 {
+		t.Errorf("rune 30000000001 = %v, want the encoded ancient rune", r)
+	}
+}
@@ -0,0 +1,177 @@
+package derived
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// Derived events are published with the request of the game api event they were derived from. Their response
+// always has the field `source_command` and the fields listed below.
+const (
+	// RuneDropped is published for every rune in the rewards of a battle, with the field `rune`
+	RuneDropped = events.DerivedNamespace + ".RuneDropped"
+	// MonsterSummoned is published for every summoned unit, with the fields `unit` and `monster` (the master
+	// data of the unit, null if it is unknown)
+	MonsterSummoned = events.DerivedNamespace + ".MonsterSummoned"
+	// EnergyRefilled is published when the energy of a wizard is refilled in the shop, with the fields
+	// `wizard_id`, `energy_before`, `energy` and `crystals_spent` (energy_before and crystals_spent are null if
+	// the wizard info before the refill is unknown)
+	EnergyRefilled = events.DerivedNamespace + ".EnergyRefilled"
+	// ArenaBattleFinished is published after every arena battle, with the fields `win`,
+	// `opponent_wizard_id` and `reward`
+	ArenaBattleFinished = events.DerivedNamespace + ".ArenaBattleFinished"
+)
+
+// energyRefillItemId is the shop item of the energy refill, see EnergyRefilled
+const energyRefillItemId = 100001
+
+// Deriver publishes high-level events that are derived from game api events, so consumers don't need to
+// extract them from the raw responses themselves.
+//
+// Deriver is an in-process plugin, see pmanager.Plugin.
+type Deriver struct {
+	log       zerolog.Logger
+	publisher events.Publisher
+
+	// wizardInfos are the last known wizard infos by wizard id, they are only used by the plugin goroutine
+	wizardInfos map[int]gamemodels.WizardInfo
+}
+
+func New(publisher events.Publisher) *Deriver {
+	return &Deriver{
+		log:         log.With().Timestamp().Str("log_type", "module").Str("module", "DerivedEvents").Logger(),
+		publisher:   publisher,
+		wizardInfos: map[int]gamemodels.WizardInfo{},
+	}
+}
+
+func (d *Deriver) Name() string {
+	return "derived"
+}
+
+// Commands subscribes to all game commands, the wizard infos are tracked from all responses
+func (d *Deriver) Commands() []string {
+	return []string{"*"}
+}
+
+type gameResponse struct {
+	gamemodels.ApiResponse
+	WizardInfo      *gamemodels.WizardInfo `json:"wizard_info"`
+	UnitList        []json.RawMessage      `json:"unit_list"`
+	ChangedItemList []struct {
+		Type gamemodels.GameItem `json:"type"`
+		Info json.RawMessage     `json:"info"`
+	} `json:"changed_item_list"`
+	WinLose gamemodels.BattleOutcome `json:"win_lose"`
+	Reward  interface{}              `json:"reward"`
+}
+
+func (d *Deriver) Handle(_ context.Context, ev events.ApiEventMsg) error {
+	if events.Namespace(ev.Command) != "" {
+		return nil
+	}
+
+	var response gameResponse
+	if err := json.Unmarshal([]byte(ev.Response), &response); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", ev.Command, err)
+	}
+	if response.RetCode != 0 {
+		return nil
+	}
+
+	if strings.HasPrefix(ev.Command, "Battle") {
+		for _, changed := range response.ChangedItemList {
+			if changed.Type == gamemodels.CategoryRune {
+				d.publish(RuneDropped, ev, map[string]interface{}{"rune": changed.Info})
+			}
+		}
+	}
+
+	switch ev.Command {
+	case "SummonUnit":
+		for _, rawUnit := range response.UnitList {
+			var unit gamemodels.Unit
+			if err := json.Unmarshal(rawUnit, &unit); err != nil {
+				return fmt.Errorf("failed to decode summoned unit: %w", err)
+			}
+
+			var monster interface{}
+			if m, ok := unit.Monster(); ok {
+				monster = m
+			}
+			d.publish(MonsterSummoned, ev, map[string]interface{}{"unit": rawUnit, "monster": monster})
+		}
+	case "BattleArenaResult":
+		var request struct {
+			OpponentWizardId int `json:"opp_wizard_id"`
+		}
+		if err := json.Unmarshal([]byte(ev.Request), &request); err != nil {
+			return fmt.Errorf("failed to decode request of %s: %w", ev.Command, err)
+		}
+
+		d.publish(ArenaBattleFinished, ev, map[string]interface{}{
+			"win":                response.WinLose == gamemodels.BattleWin,
+			"opponent_wizard_id": request.OpponentWizardId,
+			"reward":             response.Reward,
+		})
+	case "BuyShopItem":
+		var request struct {
+			ItemId int `json:"item_id"`
+		}
+		if err := json.Unmarshal([]byte(ev.Request), &request); err != nil {
+			return fmt.Errorf("failed to decode request of %s: %w", ev.Command, err)
+		}
+
+		if request.ItemId == energyRefillItemId && response.WizardInfo != nil {
+			d.energyRefilled(ev, *response.WizardInfo)
+		}
+	}
+
+	if response.WizardInfo != nil {
+		d.wizardInfos[response.WizardInfo.WizardId] = *response.WizardInfo
+	}
+
+	return nil
+}
+
+// energyRefilled publishes a refill with the energy and crystals of the wizard before the refill, if they are known
+func (d *Deriver) energyRefilled(ev events.ApiEventMsg, wizardInfo gamemodels.WizardInfo) {
+	var energyBefore, crystalsSpent interface{}
+	if previous, ok := d.wizardInfos[wizardInfo.WizardId]; ok {
+		energyBefore, crystalsSpent = previous.WizardEnergy, previous.WizardCrystal-wizardInfo.WizardCrystal
+	}
+
+	d.publish(EnergyRefilled, ev, map[string]interface{}{
+		"wizard_id":      wizardInfo.WizardId,
+		"energy_before":  energyBefore,
+		"energy":         wizardInfo.WizardEnergy,
+		"crystals_spent": crystalsSpent,
+	})
+}
+
+func (d *Deriver) publish(command string, ev events.ApiEventMsg, fields map[string]interface{}) {
+	fields["source_command"] = ev.Command
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		d.log.Error().Err(err).Str("command", command).Msg("Failed to encode derived event")
+		return
+	}
+
+	d.log.Debug().Str("command", command).Str("sourceCommand", ev.Command).Msg("Publishing derived event")
+
+	d.publisher.Publish(command, events.ApiEventMsg{
+		Command:  command,
+		Request:  ev.Request,
+		Response: string(data),
+		Metadata: ev.Metadata,
+	})
+}
@@ -0,0 +1,105 @@
+package derived
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelstest"
+)
+
+type publishedEvent struct {
+	topic    string
+	response map[string]interface{}
+}
+
+func newDeriver(t *testing.T) (*Deriver, *[]publishedEvent) {
+	t.Helper()
+
+	var published []publishedEvent
+	d := New(events.PublisherFunc(func(topic string, msg events.ApiEventMsg) {
+		var response map[string]interface{}
+		if err := json.Unmarshal([]byte(msg.Response), &response); err != nil {
+			t.Fatal(err)
+		}
+		published = append(published, publishedEvent{topic: topic, response: response})
+	}))
+	return d, &published
+}
+
+func TestEnergyRefilled(t *testing.T) {
+	refill := gamemodelstest.Event(t, "BuyShopItem.refill")
+
+	tests := []struct {
+		name          string
+		before        []string
+		energyBefore  interface{}
+		crystalsSpent interface{}
+	}{
+		// regenerated energy and crystals spent on something else are no refill
+		{"known wizard info", []string{"GetWizardInfo", "BuyShopItem"}, float64(131), float64(30)},
+		{"unknown wizard info", nil, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, published := newDeriver(t)
+			for _, name := range tt.before {
+				gamemodelstest.Handle(t, d, gamemodelstest.Event(t, name))
+			}
+			if len(*published) != 0 {
+				t.Fatalf("published %+v without a refill", *published)
+			}
+
+			// failed purchases are ignored
+			gamemodelstest.Handle(t, d, gamemodelstest.Failed(refill), refill)
+			if len(*published) != 1 {
+				t.Fatalf("published %d events, want 1", len(*published))
+			}
+
+			ev := (*published)[0]
+			if ev.topic != EnergyRefilled || ev.response["source_command"] != "BuyShopItem" {
+				t.Errorf("published %s from %v, want %s from BuyShopItem", ev.topic, ev.response["source_command"], EnergyRefilled)
+			}
+			if ev.response["wizard_id"] != float64(gamemodelstest.WizardId) || ev.response["energy_before"] != tt.energyBefore ||
+				ev.response["energy"] != float64(246) || ev.response["crystals_spent"] != tt.crystalsSpent {
+				t.Errorf("event = %v, want energy %v -> 246 for %v crystals", ev.response, tt.energyBefore, tt.crystalsSpent)
+			}
+		})
+	}
+}
+
+func TestDerivedEvents(t *testing.T) {
+	d, published := newDeriver(t)
+
+	result := gamemodelstest.Event(t, "BattleDungeonResult_V2")
+	gamemodelstest.Handle(t, d,
+		result,
+		gamemodelstest.Event(t, "SummonUnit"),
+		gamemodelstest.Event(t, "BattleArenaResult"),
+		// derived events are not derived again
+		events.ApiEventMsg{Command: RuneDropped, Request: result.Request, Response: result.Response},
+	)
+
+	topics := []string{RuneDropped, RuneDropped, MonsterSummoned, MonsterSummoned, ArenaBattleFinished}
+	if len(*published) != len(topics) {
+		t.Fatalf("published %d events, want %d", len(*published), len(topics))
+	}
+	for i, topic := range topics {
+		if (*published)[i].topic != topic {
+			t.Errorf("event %d was published on %s, want %s", i, (*published)[i].topic, topic)
+		}
+	}
+
+	if r, ok := (*published)[1].response["rune"].(map[string]interface{}); !ok || r["rune_id"] != float64(30000000021) {
+		t.Errorf("second dropped rune = %v, want rune 30000000021", (*published)[1].response["rune"])
+	}
+	if monster, ok := (*published)[2].response["monster"].(map[string]interface{}); !ok || monster["name"] != "Wind Joker" {
+		t.Errorf("monster of unit 9000000004 = %v, want Wind Joker", (*published)[2].response["monster"])
+	}
+	if monster := (*published)[3].response["monster"]; monster != nil {
+		t.Errorf("monster of unit 9000000005 = %v, want null for an unknown monster", monster)
+	}
+	if arena := (*published)[4].response; arena["win"] != true || arena["opponent_wizard_id"] != float64(87654321) {
+		t.Errorf("arena battle = %v, want a win against wizard 87654321", arena)
+	}
+}
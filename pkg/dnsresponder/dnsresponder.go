@@ -0,0 +1,223 @@
+package dnsresponder
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const maxPacketSize = 4096
+
+type Configuration struct {
+	// UpstreamResolver receives all queries that are not redirected to the proxy
+	UpstreamResolver string `default:"1.1.1.1:53"`
+	// ProxyIp is returned for all redirected hosts
+	ProxyIp net.IP
+	// Ttl of the redirected answers in seconds
+	Ttl uint32 `default:"60"`
+	// UpstreamTimeout limits how long a forwarded query may take
+	UpstreamTimeout time.Duration `default:"5s"`
+	// AllowClient decides which clients may send queries, all clients are allowed if it is nil. Queries of
+	// other clients are dropped, so the responder can't be abused as open resolver.
+	AllowClient func(addr string) bool
+}
+
+// Responder is a small DNS server that answers queries for redirected hosts with the IP of the proxy
+// and forwards every other query to an upstream resolver
+type Responder struct {
+	log           zerolog.Logger
+	configuration Configuration
+	redirectHost  func(host string) bool
+
+	mu     sync.Mutex
+	conns  []net.PacketConn
+	closed bool
+}
+
+// dnsresponder.New : Create a new DNS responder. redirectHost decides which host names are answered with ProxyIp.
+func New(configuration Configuration, redirectHost func(host string) bool) (*Responder, error) {
+	if configuration.ProxyIp.To4() == nil {
+		return nil, errors.New("proxy ip must be a valid IPv4 address")
+	}
+	if configuration.UpstreamResolver == "" {
+		configuration.UpstreamResolver = "1.1.1.1:53"
+	}
+	if configuration.Ttl == 0 {
+		configuration.Ttl = 60
+	}
+	if configuration.UpstreamTimeout == 0 {
+		configuration.UpstreamTimeout = 5 * time.Second
+	}
+
+	return &Responder{
+		log:           log.With().Timestamp().Str("log_type", "module").Str("module", "DNSResponder").Logger(),
+		configuration: configuration,
+		redirectHost:  redirectHost,
+	}, nil
+}
+
+// ListenAndServe listens for DNS queries over UDP on addr
+func (r *Responder) ListenAndServe(addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+
+	return r.Serve(conn)
+}
+
+// Serve answers DNS queries received on conn until the responder is closed
+func (r *Responder) Serve(conn net.PacketConn) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		_ = conn.Close()
+		return errors.New("dns responder is closed")
+	}
+	r.conns = append(r.conns, conn)
+	r.mu.Unlock()
+
+	r.log.Info().
+		Str("listenAddr", conn.LocalAddr().String()).
+		Str("upstreamResolver", r.configuration.UpstreamResolver).
+		Str("proxyIp", r.configuration.ProxyIp.String()).
+		Msgf("Answering DNS queries at %s", conn.LocalAddr())
+
+	for {
+		buf := make([]byte, maxPacketSize)
+		n, clientAddr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		go r.handleQuery(conn, clientAddr, buf[:n])
+	}
+}
+
+// Close stops all listeners
+func (r *Responder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	for _, conn := range r.conns {
+		_ = conn.Close()
+	}
+	r.conns = nil
+
+	return nil
+}
+
+func (r *Responder) handleQuery(conn net.PacketConn, clientAddr net.Addr, query []byte) {
+	queryLogger := r.log.With().Str("clientAddr", clientAddr.String()).Logger()
+
+	if r.configuration.AllowClient != nil && !r.configuration.AllowClient(clientAddr.String()) {
+		queryLogger.Debug().Msg("Dropped dns query of client outside of the allowed networks")
+		return
+	}
+
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		queryLogger.Debug().Err(err).Msg("could not parse dns query")
+		return
+	}
+
+	question, err := parser.Question()
+	if err != nil {
+		queryLogger.Debug().Err(err).Msg("could not parse dns question")
+		return
+	}
+
+	host := strings.TrimSuffix(question.Name.String(), ".")
+	if question.Class != dnsmessage.ClassINET || !r.redirectHost(host) {
+		r.forwardQuery(conn, clientAddr, query, queryLogger)
+		return
+	}
+
+	answer, err := r.buildRedirectAnswer(header, question)
+	if err != nil {
+		queryLogger.Error().Err(err).Str("host", host).Msg("could not build dns answer")
+		return
+	}
+
+	queryLogger.Debug().
+		Str("host", host).
+		Stringer("type", question.Type).
+		Msg("Redirecting host to proxy")
+
+	if _, err := conn.WriteTo(answer, clientAddr); err != nil {
+		queryLogger.Debug().Err(err).Msg("could not send dns answer")
+	}
+}
+
+// buildRedirectAnswer answers A queries with the proxy ip. All other query types for redirected hosts
+// get an empty answer, so clients don't bypass the proxy using e.g. IPv6.
+func (r *Responder) buildRedirectAnswer(queryHeader dnsmessage.Header, question dnsmessage.Question) ([]byte, error) {
+	builder := dnsmessage.NewBuilder(make([]byte, 0, 512), dnsmessage.Header{
+		ID:                 queryHeader.ID,
+		Response:           true,
+		Authoritative:      true,
+		RecursionDesired:   queryHeader.RecursionDesired,
+		RecursionAvailable: true,
+		RCode:              dnsmessage.RCodeSuccess,
+	})
+	builder.EnableCompression()
+
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(question); err != nil {
+		return nil, err
+	}
+
+	if question.Type == dnsmessage.TypeA {
+		if err := builder.StartAnswers(); err != nil {
+			return nil, err
+		}
+
+		var ip [4]byte
+		copy(ip[:], r.configuration.ProxyIp.To4())
+		if err := builder.AResource(dnsmessage.ResourceHeader{
+			Name:  question.Name,
+			Class: dnsmessage.ClassINET,
+			TTL:   r.configuration.Ttl,
+		}, dnsmessage.AResource{A: ip}); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Finish()
+}
+
+func (r *Responder) forwardQuery(conn net.PacketConn, clientAddr net.Addr, query []byte, queryLogger zerolog.Logger) {
+	upstream, err := net.Dial("udp", r.configuration.UpstreamResolver)
+	if err != nil {
+		queryLogger.Warn().Err(err).Msg("could not connect to upstream resolver")
+		return
+	}
+	defer upstream.Close()
+
+	_ = upstream.SetDeadline(time.Now().Add(r.configuration.UpstreamTimeout))
+	if _, err := upstream.Write(query); err != nil {
+		queryLogger.Warn().Err(err).Msg("could not forward dns query")
+		return
+	}
+
+	buf := make([]byte, maxPacketSize)
+	n, err := upstream.Read(buf)
+	if err != nil {
+		queryLogger.Warn().Err(err).Msg("no answer from upstream resolver")
+		return
+	}
+
+	if _, err := conn.WriteTo(buf[:n], clientAddr); err != nil {
+		queryLogger.Debug().Err(err).Msg("could not send dns answer")
+	}
+}
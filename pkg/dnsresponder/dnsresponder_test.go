@@ -0,0 +1,160 @@
+package dnsresponder
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+var upstreamIp = [4]byte{192, 0, 2, 1}
+
+// startUpstream starts a resolver stand-in that answers every A query with upstreamIp
+func startUpstream(t *testing.T) net.PacketConn {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, maxPacketSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var parser dnsmessage.Parser
+			header, err := parser.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			question, err := parser.Question()
+			if err != nil {
+				continue
+			}
+
+			builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true})
+			_ = builder.StartQuestions()
+			_ = builder.Question(question)
+			_ = builder.StartAnswers()
+			_ = builder.AResource(dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 1},
+				dnsmessage.AResource{A: upstreamIp})
+			answer, err := builder.Finish()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(answer, addr)
+		}
+	}()
+
+	return conn
+}
+
+func startResponder(t *testing.T, upstream net.Addr, allowClient func(string) bool) net.Addr {
+	t.Helper()
+
+	responder, err := New(Configuration{
+		UpstreamResolver: upstream.String(),
+		ProxyIp:          net.IPv4(10, 0, 0, 1),
+		UpstreamTimeout:  time.Second,
+		AllowClient:      allowClient,
+	}, func(host string) bool { return host == "summonerswar-gb.qpyou.cn" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = responder.Serve(conn) }()
+	t.Cleanup(func() { _ = responder.Close() })
+
+	return conn.LocalAddr()
+}
+
+// query sends an A query for host and returns the answered addresses, nil if there was no answer
+func query(t *testing.T, server net.Addr, host string) [][4]byte {
+	t.Helper()
+
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 42, RecursionDesired: true})
+	_ = builder.StartQuestions()
+	_ = builder.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(host + "."),
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	})
+	msg, err := builder.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := net.Dial("udp", server.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+	buf := make([]byte, maxPacketSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil
+	}
+
+	var answer dnsmessage.Message
+	if err := answer.Unpack(buf[:n]); err != nil {
+		t.Fatal(err)
+	}
+	if answer.Header.ID != 42 {
+		t.Errorf("answer has id %d, want 42", answer.Header.ID)
+	}
+
+	ips := [][4]byte{}
+	for _, resource := range answer.Answers {
+		if a, ok := resource.Body.(*dnsmessage.AResource); ok {
+			ips = append(ips, a.A)
+		}
+	}
+	return ips
+}
+
+func TestResponder(t *testing.T) {
+	upstream := startUpstream(t)
+	server := startResponder(t, upstream.LocalAddr(), nil)
+
+	tests := []struct {
+		host string
+		want [4]byte
+	}{
+		{"summonerswar-gb.qpyou.cn", [4]byte{10, 0, 0, 1}},
+		{"example.com", upstreamIp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			ips := query(t, server, tt.host)
+			if len(ips) != 1 || ips[0] != tt.want {
+				t.Errorf("query(%s) = %v, want [%v]", tt.host, ips, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponderDropsDisallowedClients(t *testing.T) {
+	upstream := startUpstream(t)
+	server := startResponder(t, upstream.LocalAddr(), func(string) bool { return false })
+
+	for _, host := range []string{"summonerswar-gb.qpyou.cn", "example.com"} {
+		if ips := query(t, server, host); ips != nil {
+			t.Errorf("query(%s) of a disallowed client = %v, want no answer", host, ips)
+		}
+	}
+}
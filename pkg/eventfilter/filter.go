@@ -0,0 +1,90 @@
+package eventfilter
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+// FilterMetadataKey is the grpc metadata key proxy api consumers use to send their filter expression on registration
+const FilterMetadataKey = "x-swarpf-filter"
+
+// Filter is a compiled predicate over an api event. Expressions address the event as a JSON document with
+// the fields `command`, `request` and `response`, e.g.
+//
+//	response.win_lose == 1 && any(response.changed_item_list, type == 8 && info.class == 6 && info.rank == 5)
+//
+// Comparisons with paths that match multiple values (`response.unit_list[*].class == 6`) are true if any of
+// the values matches. Missing values never match.
+type Filter struct {
+	expression string
+	root       node
+}
+
+// Compile parses a filter expression
+func Compile(expression string) (*Filter, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, unexpected(t, "the end of the expression")
+	}
+
+	return &Filter{expression: expression, root: root}, nil
+}
+
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.expression
+}
+
+// Match evaluates the filter against the decoded event
+func (f *Filter) Match(doc *Document) bool {
+	return truthy(f.root.eval(doc.Value()))
+}
+
+// Document is the decoded JSON representation of an api event. It is decoded on first use, so an event
+// is only decoded once no matter how many filters are evaluated against it.
+type Document struct {
+	msg events.ApiEventMsg
+
+	once  sync.Once
+	value map[string]interface{}
+}
+
+func NewDocument(msg events.ApiEventMsg) *Document {
+	return &Document{msg: msg}
+}
+
+// Value returns the decoded event. Request or response are null if they are not valid JSON.
+func (d *Document) Value() interface{} {
+	d.once.Do(func() {
+		var request, response interface{}
+		_ = json.Unmarshal([]byte(d.msg.Request), &request)
+		_ = json.Unmarshal([]byte(d.msg.Response), &response)
+
+		d.value = map[string]interface{}{
+			"command":  d.msg.Command,
+			"request":  request,
+			"response": response,
+		}
+	})
+
+	return d.value
+}
+
+// Payloads returns the decoded request and response of the event
+func (d *Document) Payloads() (request, response interface{}) {
+	value := d.Value().(map[string]interface{})
+	return value["request"], value["response"]
+}
@@ -0,0 +1,158 @@
+package eventfilter
+
+import (
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+var battleResult = events.ApiEventMsg{
+	Command: "BattleDungeonResult_V2",
+	Request: `{"dungeon_id":8001,"stage_id":10,"win_lose":1}`,
+	Response: `{
+		"ret_code": 0,
+		"win_lose": 1,
+		"wizard_info": {"wizard_id": 1, "wizard_name": "tester", "wizard_mana": 1500},
+		"changed_item_list": [
+			{"type": 8, "info": {"rune_id": 11, "class": 6, "rank": 5, "set_id": 13}},
+			{"type": 29, "info": {"item_master_id": 1001, "item_quantity": 3}}
+		],
+		"unit_list": {"100": {"unit_id": 100, "class": 6}, "101": {"unit_id": 101, "class": 4}},
+		"reward": null
+	}`,
+}
+
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{`command == "BattleDungeonResult_V2"`, true},
+		{`command == 'BattleDungeonResult_V2' && request.stage_id == 10`, true},
+		{`command != "BattleDungeonResult_V2"`, false},
+		{`response.win_lose`, true},
+		{`!response.win_lose`, false},
+		{`response.reward`, false},
+		{`response.reward == null`, true},
+		{`response.missing == null`, false},
+		{`response.missing != 1`, false},
+		{`response.wizard_info.wizard_mana >= 1500 && response.wizard_info.wizard_mana < 1501`, true},
+		{`response.wizard_info.wizard_mana > 1500`, false},
+		{`response.wizard_info.wizard_name > "a"`, true},
+		{`response.wizard_info.wizard_name == 1`, false},
+		{`response.wizard_info.wizard_name != 1`, true},
+		{`response.changed_item_list[*].type == 29`, true},
+		{`response.changed_item_list[0].type == 29`, false},
+		{`response.changed_item_list[5].type == 29`, false},
+		{`any(response.changed_item_list, type == 8 && info.class == 6 && info.rank == 5)`, true},
+		{`any(response.changed_item_list, type == 8 && info.class == 5)`, false},
+		{`any(response.unit_list, class == 6)`, true},
+		{`any(response.unit_list, class == 5)`, false},
+		{`exists(response.wizard_info.wizard_id)`, true},
+		{`exists(response.wizard_info.missing)`, false},
+		{`len(response.changed_item_list) == 2`, true},
+		{`len(response.unit_list) == 2 && len(response.wizard_info.wizard_name) == 6`, true},
+		{`false || (true && !false)`, true},
+		{`response.win_lose == 0 || request.dungeon_id == 8001`, true},
+		{`-1 < 0`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			filter, err := Compile(tt.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filter.Match(NewDocument(battleResult)); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterMatchLostBattle(t *testing.T) {
+	// the game sends win_lose 2 for a loss, which is truthy
+	doc := NewDocument(events.ApiEventMsg{
+		Command:  "BattleDungeonResult_V2",
+		Response: `{"win_lose": 2, "changed_item_list": [{"type": 8, "info": {"class": 6, "rank": 5}}]}`,
+	})
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{`response.win_lose == 1 && any(response.changed_item_list, type == 8 && info.class == 6 && info.rank == 5)`, false},
+		{`response.win_lose == 1`, false},
+		{`response.win_lose`, true},
+	}
+	for _, tt := range tests {
+		filter, err := Compile(tt.expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := filter.Match(doc); got != tt.want {
+			t.Errorf("%s: Match() = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestFilterMatchInvalidPayload(t *testing.T) {
+	doc := NewDocument(events.ApiEventMsg{Command: "HubUserLogin", Request: "not json", Response: "{"})
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{`command == "HubUserLogin"`, true},
+		{`request == null && response == null`, true},
+		{`exists(response.wizard_info)`, false},
+	}
+	for _, tt := range tests {
+		filter, err := Compile(tt.expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := filter.Match(doc); got != tt.want {
+			t.Errorf("%s: Match() = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []string{
+		``,
+		`command ==`,
+		`command == "unterminated`,
+		`(command == "a"`,
+		`command == "a")`,
+		`command == "a" command`,
+		`unknown(response.unit_list)`,
+		`any(response.unit_list class == 6)`,
+		`any("unit_list", class == 6)`,
+		`exists(response.unit_list`,
+		`response.unit_list[x] == 1`,
+		`response..unit_list`,
+		`1.2.3 == 1`,
+		`command # "a"`,
+		`&& true`,
+	}
+	for _, expression := range tests {
+		if filter, err := Compile(expression); err == nil {
+			t.Errorf("Compile(%q) = %v, want an error", expression, filter)
+		}
+	}
+}
+
+func TestFilterString(t *testing.T) {
+	filter, err := Compile(`response.win_lose`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := filter.String(); got != "response.win_lose" {
+		t.Errorf("String() = %q, want the expression", got)
+	}
+
+	var none *Filter
+	if got := none.String(); got != "" {
+		t.Errorf("String() of a nil filter = %q, want empty", got)
+	}
+}
@@ -0,0 +1,116 @@
+package eventfilter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPath
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value interface{}
+	pos   int
+}
+
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!"}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(expression); {
+		c := expression[pos]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			pos++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			pos++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			pos++
+		case c == '"' || c == '\'':
+			end := pos + 1
+			for end < len(expression) && expression[end] != c {
+				if expression[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expression) {
+				return nil, fmt.Errorf("unterminated string at position %d", pos)
+			}
+
+			raw := expression[pos : end+1]
+			if c == '\'' {
+				raw = `"` + strings.Replace(raw[1:len(raw)-1], `"`, `\"`, -1) + `"`
+			}
+			value, err := strconv.Unquote(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %w", pos, err)
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: expression[pos : end+1], value: value, pos: pos})
+			pos = end + 1
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := pos + 1
+			for end < len(expression) && strings.IndexByte("0123456789.eE+-", expression[end]) >= 0 {
+				end++
+			}
+
+			value, err := strconv.ParseFloat(expression[pos:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", expression[pos:end], pos)
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, text: expression[pos:end], value: value, pos: pos})
+			pos = end
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			end := pos + 1
+			for end < len(expression) && isPathChar(expression[end]) {
+				end++
+			}
+
+			tokens = append(tokens, token{kind: tokenPath, text: expression[pos:end], pos: pos})
+			pos = end
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(expression[pos:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+					pos += len(op)
+					matched = true
+					break
+				}
+			}
+
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(expression)}), nil
+}
+
+func isPathChar(c byte) bool {
+	return c == '_' || c == '.' || c == '[' || c == ']' || c == '*' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
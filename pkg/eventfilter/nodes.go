@@ -0,0 +1,223 @@
+package eventfilter
+
+import (
+	"github.com/swarpf/proxy/pkg/jsonpath"
+)
+
+// node is an evaluable part of an expression. Every node evaluates to a set of values, since paths with
+// wildcards can match multiple values. Boolean nodes evaluate to a single bool.
+type node interface {
+	eval(scope interface{}) []interface{}
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n literalNode) eval(interface{}) []interface{} {
+	return []interface{}{n.value}
+}
+
+type pathNode struct {
+	path jsonpath.Path
+}
+
+func (n pathNode) eval(scope interface{}) []interface{} {
+	return n.path.Get(scope)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(scope interface{}) []interface{} {
+	return []interface{}{!truthy(n.operand.eval(scope))}
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(scope interface{}) []interface{} {
+	return []interface{}{truthy(n.left.eval(scope)) && truthy(n.right.eval(scope))}
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(scope interface{}) []interface{} {
+	return []interface{}{truthy(n.left.eval(scope)) || truthy(n.right.eval(scope))}
+}
+
+// compareNode is true if any combination of the left and right values satisfies the comparison
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n compareNode) eval(scope interface{}) []interface{} {
+	for _, left := range n.left.eval(scope) {
+		for _, right := range n.right.eval(scope) {
+			if compare(n.op, left, right) {
+				return []interface{}{true}
+			}
+		}
+	}
+	return []interface{}{false}
+}
+
+// anyNode is true if the predicate holds for at least one element of the arrays (or objects) matched by
+// path. Paths inside of the predicate are relative to the element.
+type anyNode struct {
+	path      jsonpath.Path
+	predicate node
+}
+
+func (n anyNode) eval(scope interface{}) []interface{} {
+	for _, value := range n.path.Get(scope) {
+		for _, element := range elements(value) {
+			if truthy(n.predicate.eval(element)) {
+				return []interface{}{true}
+			}
+		}
+	}
+	return []interface{}{false}
+}
+
+type existsNode struct {
+	path jsonpath.Path
+}
+
+func (n existsNode) eval(scope interface{}) []interface{} {
+	return []interface{}{len(n.path.Get(scope)) > 0}
+}
+
+type lenNode struct {
+	path jsonpath.Path
+}
+
+func (n lenNode) eval(scope interface{}) []interface{} {
+	var lengths []interface{}
+	for _, value := range n.path.Get(scope) {
+		switch v := value.(type) {
+		case []interface{}:
+			lengths = append(lengths, float64(len(v)))
+		case map[string]interface{}:
+			lengths = append(lengths, float64(len(v)))
+		case string:
+			lengths = append(lengths, float64(len(v)))
+		}
+	}
+	return lengths
+}
+
+// elements returns the elements of arrays and the values of objects, the game uses both for lists
+func elements(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			values = append(values, element)
+		}
+		return values
+	}
+	return []interface{}{value}
+}
+
+// truthy is true if any of the values is neither false, null, zero nor empty
+func truthy(values []interface{}) bool {
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case bool:
+			if v {
+				return true
+			}
+		case float64:
+			if v != 0 {
+				return true
+			}
+		case string:
+			if v != "" {
+				return true
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				return true
+			}
+		case map[string]interface{}:
+			if len(v) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func compare(op string, left, right interface{}) bool {
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return l == r
+		case "!=":
+			return l != r
+		case "<":
+			return l < r
+		case "<=":
+			return l <= r
+		case ">":
+			return l > r
+		case ">=":
+			return l >= r
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return l == r
+		case "!=":
+			return l != r
+		case "<":
+			return l < r
+		case "<=":
+			return l <= r
+		case ">":
+			return l > r
+		case ">=":
+			return l >= r
+		}
+	case bool:
+		r, ok := right.(bool)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return l == r
+		case "!=":
+			return l != r
+		}
+	case nil:
+		switch op {
+		case "==":
+			return right == nil
+		case "!=":
+			return right != nil
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,195 @@
+package eventfilter
+
+import (
+	"fmt"
+
+	"github.com/swarpf/proxy/pkg/jsonpath"
+)
+
+// parser is a recursive descent parser for the following grammar:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | comparison
+//	comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) operand ]
+//	operand    = literal | path | "(" or ")"
+//	           | "any" "(" path "," or ")" | "exists" "(" path ")" | "len" "(" path ")"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if t := p.next(); t.kind != kind {
+		return unexpected(t, text)
+	}
+	return nil
+}
+
+func unexpected(t token, expected string) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("expected %s but the expression ended", expected)
+	}
+	return fmt.Errorf("expected %s but found %q at position %d", expected, t.text, t.pos)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOperator && p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOperator && p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind == tokenOperator && p.peek().text == "!" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return left, nil
+	}
+
+	switch t.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op: t.text, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber, tokenString:
+		return literalNode{value: t.value}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tokenPath:
+		switch t.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null":
+			return literalNode{value: nil}, nil
+		}
+
+		if p.peek().kind == tokenLParen {
+			return p.parseFunction(t)
+		}
+
+		path, err := jsonpath.Parse(t.text)
+		if err != nil {
+			return nil, err
+		}
+		return pathNode{path: path}, nil
+	}
+
+	return nil, unexpected(t, "a value, path or function")
+}
+
+func (p *parser) parseFunction(name token) (node, error) {
+	p.next()
+
+	pathToken := p.next()
+	if pathToken.kind != tokenPath {
+		return nil, unexpected(pathToken, "a path")
+	}
+	path, err := jsonpath.Parse(pathToken.text)
+	if err != nil {
+		return nil, err
+	}
+
+	var fn node
+	switch name.text {
+	case "any":
+		if err := p.expect(tokenComma, ","); err != nil {
+			return nil, err
+		}
+		predicate, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		fn = anyNode{path: path, predicate: predicate}
+	case "exists":
+		fn = existsNode{path: path}
+	case "len":
+		fn = lenNode{path: path}
+	default:
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+
+	if err := p.expect(tokenRParen, ")"); err != nil {
+		return nil, err
+	}
+	return fn, nil
+}
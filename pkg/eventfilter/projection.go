@@ -0,0 +1,84 @@
+package eventfilter
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/jsonpath"
+)
+
+// ProjectionMetadataKey is the grpc metadata key proxy api consumers use to send their projection paths on registration
+const ProjectionMetadataKey = "x-swarpf-projection"
+
+// Projection reduces the request and response of api events to the sub-documents addressed by a list of paths,
+// e.g. `response.wizard_info` and `response.unit_list[*].unit_master_id`. Paths have to start with `request.`
+// or `response.`. If no path addresses the request (or response) it is kept as it is.
+type Projection struct {
+	paths    []string
+	request  []jsonpath.Path
+	response []jsonpath.Path
+}
+
+// CompileProjection parses the paths of a projection
+func CompileProjection(paths []string) (*Projection, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("projection must contain at least one path")
+	}
+
+	p := &Projection{paths: paths}
+	for _, raw := range paths {
+		var target *[]jsonpath.Path
+		switch {
+		case strings.HasPrefix(raw, "request."):
+			target, raw = &p.request, strings.TrimPrefix(raw, "request.")
+		case strings.HasPrefix(raw, "response."):
+			target, raw = &p.response, strings.TrimPrefix(raw, "response.")
+		default:
+			return nil, errors.New("projection path " + raw + " has to start with request. or response.")
+		}
+
+		path, err := jsonpath.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		*target = append(*target, path)
+	}
+
+	return p, nil
+}
+
+func (p *Projection) String() string {
+	if p == nil {
+		return ""
+	}
+	return strings.Join(p.paths, ",")
+}
+
+// Apply returns the event with its request and response reduced to the projected sub-documents
+func (p *Projection) Apply(doc *Document) events.ApiEventMsg {
+	request, response := doc.Payloads()
+
+	msg := doc.msg
+	if len(p.request) > 0 {
+		msg.Request = projectJson(request, p.request)
+	}
+	if len(p.response) > 0 {
+		msg.Response = projectJson(response, p.response)
+	}
+	return msg
+}
+
+func projectJson(value interface{}, paths []jsonpath.Path) string {
+	projected := jsonpath.Project(value, paths)
+	if projected == nil {
+		return "{}"
+	}
+
+	data, err := json.Marshal(projected)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
@@ -0,0 +1,81 @@
+package eventfilter
+
+import (
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+func TestProjectionApply(t *testing.T) {
+	tests := []struct {
+		name         string
+		paths        []string
+		wantRequest  string
+		wantResponse string
+	}{
+		{
+			name:         "response only",
+			paths:        []string{"response.wizard_info.wizard_mana"},
+			wantRequest:  battleResult.Request,
+			wantResponse: `{"wizard_info":{"wizard_mana":1500}}`,
+		},
+		{
+			name:         "request and response",
+			paths:        []string{"request.stage_id", "response.changed_item_list[*].type"},
+			wantRequest:  `{"stage_id":10}`,
+			wantResponse: `{"changed_item_list":[{"type":8},{"type":29}]}`,
+		},
+		{
+			name:         "missing",
+			paths:        []string{"response.missing"},
+			wantRequest:  battleResult.Request,
+			wantResponse: `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projection, err := CompileProjection(tt.paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := projection.Apply(NewDocument(battleResult))
+			if msg.Command != battleResult.Command {
+				t.Errorf("Command = %q, want %q", msg.Command, battleResult.Command)
+			}
+			if msg.Request != tt.wantRequest {
+				t.Errorf("Request = %s, want %s", msg.Request, tt.wantRequest)
+			}
+			if msg.Response != tt.wantResponse {
+				t.Errorf("Response = %s, want %s", msg.Response, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestProjectionOfInvalidPayload(t *testing.T) {
+	projection, err := CompileProjection([]string{"response.wizard_info"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := projection.Apply(NewDocument(events.ApiEventMsg{Command: "HubUserLogin", Request: "{}", Response: "{"}))
+	if msg.Response != "{}" {
+		t.Errorf("Response = %s, want {}", msg.Response)
+	}
+}
+
+func TestCompileProjectionErrors(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{"wizard_info"},
+		{"response.wizard_info", "command"},
+		{"response."},
+		{"request.unit_list[x]"},
+	}
+	for _, paths := range tests {
+		if projection, err := CompileProjection(paths); err == nil {
+			t.Errorf("CompileProjection(%q) = %v, want an error", paths, projection)
+		}
+	}
+}
@@ -4,4 +4,17 @@ type ApiEventMsg struct {
 	Command  string
 	Request  string
 	Response string
+	Metadata ExchangeMetadata
+}
+
+// Publisher publishes events to all consumers and plugins, it is implemented by pmanager.ProxyManager
+type Publisher interface {
+	Publish(topic string, msg ApiEventMsg)
+}
+
+// PublisherFunc adapts a function to the Publisher interface
+type PublisherFunc func(topic string, msg ApiEventMsg)
+
+func (f PublisherFunc) Publish(topic string, msg ApiEventMsg) {
+	f(topic, msg)
 }
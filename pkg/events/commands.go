@@ -0,0 +1,67 @@
+package events
+
+import (
+	"path"
+	"strings"
+)
+
+// Commands of events that are not sent by the game api itself are namespaced with a prefix, e.g. `error.`.
+// Game api commands never contain a dot.
+const (
+	ErrorNamespace   = "error"
+	ProxyNamespace   = "proxy"
+	DerivedNamespace = "derived"
+)
+
+// ProxyShuttingDown is sent to all consumers regardless of their command globs when the proxy shuts down
+const ProxyShuttingDown = ProxyNamespace + ".ShuttingDown"
+
+// Stages of an exchange with the game api that can fail. Failed exchanges are published with the command
+// `error.<stage>`, see ErrorCommand.
+const (
+	// StageTransport means the request could not be sent or the response could not be received
+	StageTransport = "transport"
+	// StageTimeout means the game api didn't respond in time
+	StageTimeout = "timeout"
+	// StageUpstreamStatus means the game api responded with a status code other than 2xx
+	StageUpstreamStatus = "upstream_status"
+	// StageEmptyResponse means the game api responded without a body
+	StageEmptyResponse = "empty_response"
+	// StageTruncated means the response body ended before its announced length
+	StageTruncated = "truncated"
+	// StageRequestDecode means the request could not be decrypted or parsed
+	StageRequestDecode = "request_decode"
+	// StageResponseDecode means the response could not be decrypted or decompressed
+	StageResponseDecode = "response_decode"
+)
+
+// ErrorCommand returns the command of error events for stage
+func ErrorCommand(stage string) string {
+	return ErrorNamespace + "." + stage
+}
+
+// DerivedCommand returns the command of events that the proxy derives from game api events, e.g.
+// `derived.RuneEvaluated`
+func DerivedCommand(name string) string {
+	return DerivedNamespace + "." + name
+}
+
+// Namespace returns the namespace of a command, or an empty string for game api commands
+func Namespace(command string) string {
+	if idx := strings.Index(command, "."); idx >= 0 {
+		return command[:idx]
+	}
+	return ""
+}
+
+// MatchCommand works like path.Match, but namespaced commands only match globs that start with the same
+// namespace. This way consumers that subscribe to `*` only receive game api events, while `error.*`
+// matches all error events.
+func MatchCommand(glob, command string) (bool, error) {
+	if ns := Namespace(command); ns != "" && !strings.HasPrefix(glob, ns+".") {
+		_, err := path.Match(glob, "")
+		return false, err
+	}
+
+	return path.Match(glob, command)
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"strconv"
+	"time"
+)
+
+// ExchangeMetadata describes the HTTP exchange between game client and game server an api event was captured from
+type ExchangeMetadata struct {
+	// Session is the goproxy session id of the exchange
+	Session int64
+	// ClientAddr is the address of the game client
+	ClientAddr string
+	// UpstreamHost is the game server the request was sent to and UpstreamRegion its region, e.g. `gb` or `eu`
+	UpstreamHost   string
+	UpstreamRegion string
+	// RequestStart is the time the proxy received the request from the game client
+	RequestStart time.Time
+	// UpstreamLatency is the time between forwarding the request and receiving the response headers
+	UpstreamLatency time.Duration
+	// Duration is the time between receiving the request and reading the complete response body
+	Duration time.Duration
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// RequestSize and ResponseSize are the sizes of the encrypted bodies, RequestPlainSize and
+	// ResponsePlainSize the sizes of the decrypted (and decompressed) bodies in bytes
+	RequestSize       int
+	RequestPlainSize  int
+	ResponseSize      int
+	ResponsePlainSize int
+	// Error describes why the exchange failed, it is only set for error events
+	Error string
+}
+
+// GrpcMetadataPrefix is prepended to the keys of ExchangeMetadata.Strings when they are sent as grpc metadata
+// along with an api event
+const GrpcMetadataPrefix = "x-swarpf-event-"
+
+// keys of the string representation of ExchangeMetadata
+const (
+	MetadataSession           = "session"
+	MetadataClientAddr        = "client_addr"
+	MetadataUpstreamHost      = "upstream_host"
+	MetadataUpstreamRegion    = "upstream_region"
+	MetadataRequestStart      = "request_start"
+	MetadataUpstreamLatency   = "upstream_latency_ns"
+	MetadataDuration          = "duration_ns"
+	MetadataStatusCode        = "status_code"
+	MetadataRequestSize       = "request_size"
+	MetadataRequestPlainSize  = "request_plain_size"
+	MetadataResponseSize      = "response_size"
+	MetadataResponsePlainSize = "response_plain_size"
+	MetadataError             = "error"
+)
+
+// Strings returns the metadata as key value pairs, e.g. to send it as grpc metadata. Unset fields are omitted.
+// Times are formatted as RFC 3339 and durations as nanoseconds.
+func (m ExchangeMetadata) Strings() map[string]string {
+	fields := map[string]string{}
+
+	setString := func(key, value string) {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	setInt := func(key string, value int64) {
+		if value != 0 {
+			fields[key] = strconv.FormatInt(value, 10)
+		}
+	}
+
+	setInt(MetadataSession, m.Session)
+	setString(MetadataClientAddr, m.ClientAddr)
+	setString(MetadataUpstreamHost, m.UpstreamHost)
+	setString(MetadataUpstreamRegion, m.UpstreamRegion)
+	if !m.RequestStart.IsZero() {
+		fields[MetadataRequestStart] = m.RequestStart.Format(time.RFC3339Nano)
+	}
+	setInt(MetadataUpstreamLatency, int64(m.UpstreamLatency))
+	setInt(MetadataDuration, int64(m.Duration))
+	setInt(MetadataStatusCode, int64(m.StatusCode))
+	setInt(MetadataRequestSize, int64(m.RequestSize))
+	setInt(MetadataRequestPlainSize, int64(m.RequestPlainSize))
+	setInt(MetadataResponseSize, int64(m.ResponseSize))
+	setInt(MetadataResponsePlainSize, int64(m.ResponsePlainSize))
+	setString(MetadataError, m.Error)
+
+	return fields
+}
+
+// ParseExchangeMetadata is the inverse of ExchangeMetadata.Strings. Missing or invalid fields are left unset.
+func ParseExchangeMetadata(fields map[string]string) ExchangeMetadata {
+	getInt := func(key string) int64 {
+		value, _ := strconv.ParseInt(fields[key], 10, 64)
+		return value
+	}
+
+	m := ExchangeMetadata{
+		Session:           getInt(MetadataSession),
+		ClientAddr:        fields[MetadataClientAddr],
+		UpstreamHost:      fields[MetadataUpstreamHost],
+		UpstreamRegion:    fields[MetadataUpstreamRegion],
+		UpstreamLatency:   time.Duration(getInt(MetadataUpstreamLatency)),
+		Duration:          time.Duration(getInt(MetadataDuration)),
+		StatusCode:        int(getInt(MetadataStatusCode)),
+		RequestSize:       int(getInt(MetadataRequestSize)),
+		RequestPlainSize:  int(getInt(MetadataRequestPlainSize)),
+		ResponseSize:      int(getInt(MetadataResponseSize)),
+		ResponsePlainSize: int(getInt(MetadataResponsePlainSize)),
+		Error:             fields[MetadataError],
+	}
+	if start, err := time.Parse(time.RFC3339Nano, fields[MetadataRequestStart]); err == nil {
+		m.RequestStart = start
+	}
+
+	return m
+}
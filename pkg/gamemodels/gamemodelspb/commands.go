@@ -0,0 +1,14 @@
+// Code generated by gen.go from the types of gamemodels. DO NOT EDIT.
+
+package gamemodelspb
+
+import "google.golang.org/protobuf/proto"
+
+var responseMessages = map[string]func() proto.Message{
+	"HubUserLogin":              func() proto.Message { return new(HubUserLogin) },
+	"GetWizardInfo":             func() proto.Message { return new(GetWizardInfo) },
+	"BattleDungeonStart":        func() proto.Message { return new(BattleDungeonStart) },
+	"BattleDungeonResult_V2":    func() proto.Message { return new(BattleDungeonResultV2) },
+	"BattleTrialTowerStart_v2":  func() proto.Message { return new(BattleTrialTowerStartV2) },
+	"BattleTrialTowerResult_v2": func() proto.Message { return new(BattleTrialTowerResultV2) },
+}
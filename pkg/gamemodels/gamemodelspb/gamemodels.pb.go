@@ -0,0 +1,2489 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0
+// 	protoc        (unknown)
+// source: gamemodels.proto
+
+package gamemodelspb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type HubUserLogin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command             string             `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	RetCode             int64              `protobuf:"varint,2,opt,name=ret_code,proto3" json:"ret_code,omitempty"`
+	TsVal               int64              `protobuf:"varint,3,opt,name=ts_val,proto3" json:"ts_val,omitempty"`
+	TValue              int64              `protobuf:"varint,4,opt,name=t_value,json=tvalue,proto3" json:"t_value,omitempty"`
+	TValueLocal         int64              `protobuf:"varint,5,opt,name=t_value_local,json=tvaluelocal,proto3" json:"t_value_local,omitempty"`
+	TZone               string             `protobuf:"bytes,6,opt,name=t_zone,json=tzone,proto3" json:"t_zone,omitempty"`
+	WizardInfo          *WizardInfo        `protobuf:"bytes,7,opt,name=wizard_info,proto3" json:"wizard_info,omitempty"`
+	UnitList            []*Unit            `protobuf:"bytes,8,rep,name=unit_list,proto3" json:"unit_list,omitempty"`
+	Runes               []*Rune            `protobuf:"bytes,9,rep,name=runes,proto3" json:"runes,omitempty"`
+	Artifacts           []*Artifact        `protobuf:"bytes,10,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	BuildingList        []*Building        `protobuf:"bytes,11,rep,name=building_list,proto3" json:"building_list,omitempty"`
+	HomunculusSkillList []*HomunculusSkill `protobuf:"bytes,12,rep,name=homunculus_skill_list,proto3" json:"homunculus_skill_list,omitempty"`
+}
+
+func (x *HubUserLogin) Reset() {
+	*x = HubUserLogin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HubUserLogin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HubUserLogin) ProtoMessage() {}
+
+func (x *HubUserLogin) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HubUserLogin.ProtoReflect.Descriptor instead.
+func (*HubUserLogin) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HubUserLogin) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *HubUserLogin) GetRetCode() int64 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *HubUserLogin) GetTsVal() int64 {
+	if x != nil {
+		return x.TsVal
+	}
+	return 0
+}
+
+func (x *HubUserLogin) GetTValue() int64 {
+	if x != nil {
+		return x.TValue
+	}
+	return 0
+}
+
+func (x *HubUserLogin) GetTValueLocal() int64 {
+	if x != nil {
+		return x.TValueLocal
+	}
+	return 0
+}
+
+func (x *HubUserLogin) GetTZone() string {
+	if x != nil {
+		return x.TZone
+	}
+	return ""
+}
+
+func (x *HubUserLogin) GetWizardInfo() *WizardInfo {
+	if x != nil {
+		return x.WizardInfo
+	}
+	return nil
+}
+
+func (x *HubUserLogin) GetUnitList() []*Unit {
+	if x != nil {
+		return x.UnitList
+	}
+	return nil
+}
+
+func (x *HubUserLogin) GetRunes() []*Rune {
+	if x != nil {
+		return x.Runes
+	}
+	return nil
+}
+
+func (x *HubUserLogin) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *HubUserLogin) GetBuildingList() []*Building {
+	if x != nil {
+		return x.BuildingList
+	}
+	return nil
+}
+
+func (x *HubUserLogin) GetHomunculusSkillList() []*HomunculusSkill {
+	if x != nil {
+		return x.HomunculusSkillList
+	}
+	return nil
+}
+
+type WizardInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WizardId           int64   `protobuf:"varint,1,opt,name=wizard_id,proto3" json:"wizard_id,omitempty"`
+	WizardName         string  `protobuf:"bytes,2,opt,name=wizard_name,proto3" json:"wizard_name,omitempty"`
+	WizardMana         int64   `protobuf:"varint,3,opt,name=wizard_mana,proto3" json:"wizard_mana,omitempty"`
+	WizardCrystal      int64   `protobuf:"varint,4,opt,name=wizard_crystal,proto3" json:"wizard_crystal,omitempty"`
+	WizardLevel        int64   `protobuf:"varint,5,opt,name=wizard_level,proto3" json:"wizard_level,omitempty"`
+	WizardEnergy       int64   `protobuf:"varint,6,opt,name=wizard_energy,proto3" json:"wizard_energy,omitempty"`
+	EnergyMax          int64   `protobuf:"varint,7,opt,name=energy_max,proto3" json:"energy_max,omitempty"`
+	EnergyPerMin       float32 `protobuf:"fixed32,8,opt,name=energy_per_min,proto3" json:"energy_per_min,omitempty"`
+	NextEnergyGain     int64   `protobuf:"varint,9,opt,name=next_energy_gain,proto3" json:"next_energy_gain,omitempty"`
+	PvpEvent           bool    `protobuf:"varint,10,opt,name=pvp_event,proto3" json:"pvp_event,omitempty"`
+	MailBoxEvent       bool    `protobuf:"varint,11,opt,name=mail_box_event,proto3" json:"mail_box_event,omitempty"`
+	SocialPointCurrent int64   `protobuf:"varint,12,opt,name=social_point_current,proto3" json:"social_point_current,omitempty"`
+	SocialPointMax     int64   `protobuf:"varint,13,opt,name=social_point_max,proto3" json:"social_point_max,omitempty"`
+}
+
+func (x *WizardInfo) Reset() {
+	*x = WizardInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WizardInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WizardInfo) ProtoMessage() {}
+
+func (x *WizardInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WizardInfo.ProtoReflect.Descriptor instead.
+func (*WizardInfo) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WizardInfo) GetWizardId() int64 {
+	if x != nil {
+		return x.WizardId
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetWizardName() string {
+	if x != nil {
+		return x.WizardName
+	}
+	return ""
+}
+
+func (x *WizardInfo) GetWizardMana() int64 {
+	if x != nil {
+		return x.WizardMana
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetWizardCrystal() int64 {
+	if x != nil {
+		return x.WizardCrystal
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetWizardLevel() int64 {
+	if x != nil {
+		return x.WizardLevel
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetWizardEnergy() int64 {
+	if x != nil {
+		return x.WizardEnergy
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetEnergyMax() int64 {
+	if x != nil {
+		return x.EnergyMax
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetEnergyPerMin() float32 {
+	if x != nil {
+		return x.EnergyPerMin
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetNextEnergyGain() int64 {
+	if x != nil {
+		return x.NextEnergyGain
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetPvpEvent() bool {
+	if x != nil {
+		return x.PvpEvent
+	}
+	return false
+}
+
+func (x *WizardInfo) GetMailBoxEvent() bool {
+	if x != nil {
+		return x.MailBoxEvent
+	}
+	return false
+}
+
+func (x *WizardInfo) GetSocialPointCurrent() int64 {
+	if x != nil {
+		return x.SocialPointCurrent
+	}
+	return 0
+}
+
+func (x *WizardInfo) GetSocialPointMax() int64 {
+	if x != nil {
+		return x.SocialPointMax
+	}
+	return 0
+}
+
+type Unit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnitId         int64        `protobuf:"varint,1,opt,name=unit_id,proto3" json:"unit_id,omitempty"`
+	WizardId       int64        `protobuf:"varint,2,opt,name=wizard_id,proto3" json:"wizard_id,omitempty"`
+	UnitMasterId   int64        `protobuf:"varint,3,opt,name=unit_master_id,proto3" json:"unit_master_id,omitempty"`
+	Level          int64        `protobuf:"varint,4,opt,name=level,json=unit_level,proto3" json:"level,omitempty"`
+	Stars          int64        `protobuf:"varint,5,opt,name=stars,json=class,proto3" json:"stars,omitempty"`
+	Con            int64        `protobuf:"varint,6,opt,name=con,proto3" json:"con,omitempty"`
+	Atk            int64        `protobuf:"varint,7,opt,name=atk,proto3" json:"atk,omitempty"`
+	Def            int64        `protobuf:"varint,8,opt,name=def,proto3" json:"def,omitempty"`
+	Spd            int64        `protobuf:"varint,9,opt,name=spd,proto3" json:"spd,omitempty"`
+	Resist         int64        `protobuf:"varint,10,opt,name=resist,proto3" json:"resist,omitempty"`
+	Accuracy       int64        `protobuf:"varint,11,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	CriticalRate   int64        `protobuf:"varint,12,opt,name=critical_rate,proto3" json:"critical_rate,omitempty"`
+	CriticalDamage int64        `protobuf:"varint,13,opt,name=critical_damage,proto3" json:"critical_damage,omitempty"`
+	Attribute      int64        `protobuf:"varint,14,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Homunculus     int64        `protobuf:"varint,15,opt,name=homunculus,proto3" json:"homunculus,omitempty"`
+	HomunculusName string       `protobuf:"bytes,16,opt,name=homunculus_name,proto3" json:"homunculus_name,omitempty"`
+	Skills         []*UnitSkill `protobuf:"bytes,17,rep,name=skills,proto3" json:"skills,omitempty"`
+	Runes          []*Rune      `protobuf:"bytes,18,rep,name=runes,proto3" json:"runes,omitempty"`
+	Artifacts      []*Artifact  `protobuf:"bytes,19,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+}
+
+func (x *Unit) Reset() {
+	*x = Unit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Unit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Unit) ProtoMessage() {}
+
+func (x *Unit) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Unit.ProtoReflect.Descriptor instead.
+func (*Unit) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Unit) GetUnitId() int64 {
+	if x != nil {
+		return x.UnitId
+	}
+	return 0
+}
+
+func (x *Unit) GetWizardId() int64 {
+	if x != nil {
+		return x.WizardId
+	}
+	return 0
+}
+
+func (x *Unit) GetUnitMasterId() int64 {
+	if x != nil {
+		return x.UnitMasterId
+	}
+	return 0
+}
+
+func (x *Unit) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Unit) GetStars() int64 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+func (x *Unit) GetCon() int64 {
+	if x != nil {
+		return x.Con
+	}
+	return 0
+}
+
+func (x *Unit) GetAtk() int64 {
+	if x != nil {
+		return x.Atk
+	}
+	return 0
+}
+
+func (x *Unit) GetDef() int64 {
+	if x != nil {
+		return x.Def
+	}
+	return 0
+}
+
+func (x *Unit) GetSpd() int64 {
+	if x != nil {
+		return x.Spd
+	}
+	return 0
+}
+
+func (x *Unit) GetResist() int64 {
+	if x != nil {
+		return x.Resist
+	}
+	return 0
+}
+
+func (x *Unit) GetAccuracy() int64 {
+	if x != nil {
+		return x.Accuracy
+	}
+	return 0
+}
+
+func (x *Unit) GetCriticalRate() int64 {
+	if x != nil {
+		return x.CriticalRate
+	}
+	return 0
+}
+
+func (x *Unit) GetCriticalDamage() int64 {
+	if x != nil {
+		return x.CriticalDamage
+	}
+	return 0
+}
+
+func (x *Unit) GetAttribute() int64 {
+	if x != nil {
+		return x.Attribute
+	}
+	return 0
+}
+
+func (x *Unit) GetHomunculus() int64 {
+	if x != nil {
+		return x.Homunculus
+	}
+	return 0
+}
+
+func (x *Unit) GetHomunculusName() string {
+	if x != nil {
+		return x.HomunculusName
+	}
+	return ""
+}
+
+func (x *Unit) GetSkills() []*UnitSkill {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *Unit) GetRunes() []*Rune {
+	if x != nil {
+		return x.Runes
+	}
+	return nil
+}
+
+func (x *Unit) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+type UnitSkill struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SkillId int64 `protobuf:"varint,1,opt,name=skill_id,proto3" json:"skill_id,omitempty"`
+	Level   int64 `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *UnitSkill) Reset() {
+	*x = UnitSkill{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnitSkill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitSkill) ProtoMessage() {}
+
+func (x *UnitSkill) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitSkill.ProtoReflect.Descriptor instead.
+func (*UnitSkill) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UnitSkill) GetSkillId() int64 {
+	if x != nil {
+		return x.SkillId
+	}
+	return 0
+}
+
+func (x *UnitSkill) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type Rune struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RuneId          int64       `protobuf:"varint,1,opt,name=rune_id,proto3" json:"rune_id,omitempty"`
+	WizardId        int64       `protobuf:"varint,2,opt,name=wizard_id,proto3" json:"wizard_id,omitempty"`
+	OccupiedType    int64       `protobuf:"varint,3,opt,name=occupied_type,proto3" json:"occupied_type,omitempty"`
+	OccupiedId      int64       `protobuf:"varint,4,opt,name=occupied_id,proto3" json:"occupied_id,omitempty"`
+	RuneSet         int64       `protobuf:"varint,5,opt,name=rune_set,json=set_id,proto3" json:"rune_set,omitempty"`
+	Stars           int64       `protobuf:"varint,6,opt,name=stars,json=class,proto3" json:"stars,omitempty"`
+	Level           int64       `protobuf:"varint,7,opt,name=level,json=upgrade_curr,proto3" json:"level,omitempty"`
+	Slot            int64       `protobuf:"varint,8,opt,name=slot,json=slot_no,proto3" json:"slot,omitempty"`
+	Quality         int64       `protobuf:"varint,9,opt,name=quality,json=rank,proto3" json:"quality,omitempty"`
+	OriginalQuality int64       `protobuf:"varint,10,opt,name=original_quality,json=extra,proto3" json:"original_quality,omitempty"`
+	Ancient         bool        `protobuf:"varint,11,opt,name=ancient,proto3" json:"ancient,omitempty"`
+	SellValue       int64       `protobuf:"varint,12,opt,name=sell_value,proto3" json:"sell_value,omitempty"`
+	MainStat        *RuneStat   `protobuf:"bytes,13,opt,name=main_stat,json=pri_eff,proto3" json:"main_stat,omitempty"`
+	InnateStat      *RuneStat   `protobuf:"bytes,14,opt,name=innate_stat,json=prefix_eff,proto3" json:"innate_stat,omitempty"`
+	Substats        []*RuneStat `protobuf:"bytes,15,rep,name=substats,json=sec_eff,proto3" json:"substats,omitempty"`
+}
+
+func (x *Rune) Reset() {
+	*x = Rune{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rune) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rune) ProtoMessage() {}
+
+func (x *Rune) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rune.ProtoReflect.Descriptor instead.
+func (*Rune) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Rune) GetRuneId() int64 {
+	if x != nil {
+		return x.RuneId
+	}
+	return 0
+}
+
+func (x *Rune) GetWizardId() int64 {
+	if x != nil {
+		return x.WizardId
+	}
+	return 0
+}
+
+func (x *Rune) GetOccupiedType() int64 {
+	if x != nil {
+		return x.OccupiedType
+	}
+	return 0
+}
+
+func (x *Rune) GetOccupiedId() int64 {
+	if x != nil {
+		return x.OccupiedId
+	}
+	return 0
+}
+
+func (x *Rune) GetRuneSet() int64 {
+	if x != nil {
+		return x.RuneSet
+	}
+	return 0
+}
+
+func (x *Rune) GetStars() int64 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+func (x *Rune) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Rune) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *Rune) GetQuality() int64 {
+	if x != nil {
+		return x.Quality
+	}
+	return 0
+}
+
+func (x *Rune) GetOriginalQuality() int64 {
+	if x != nil {
+		return x.OriginalQuality
+	}
+	return 0
+}
+
+func (x *Rune) GetAncient() bool {
+	if x != nil {
+		return x.Ancient
+	}
+	return false
+}
+
+func (x *Rune) GetSellValue() int64 {
+	if x != nil {
+		return x.SellValue
+	}
+	return 0
+}
+
+func (x *Rune) GetMainStat() *RuneStat {
+	if x != nil {
+		return x.MainStat
+	}
+	return nil
+}
+
+func (x *Rune) GetInnateStat() *RuneStat {
+	if x != nil {
+		return x.InnateStat
+	}
+	return nil
+}
+
+func (x *Rune) GetSubstats() []*RuneStat {
+	if x != nil {
+		return x.Substats
+	}
+	return nil
+}
+
+type RuneStat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EffectType  int64 `protobuf:"varint,1,opt,name=effect_type,proto3" json:"effect_type,omitempty"`
+	EffectValue int64 `protobuf:"varint,2,opt,name=effect_value,proto3" json:"effect_value,omitempty"`
+	IsEnchanted bool  `protobuf:"varint,3,opt,name=is_enchanted,proto3" json:"is_enchanted,omitempty"`
+	GrindValue  int64 `protobuf:"varint,4,opt,name=grind_value,proto3" json:"grind_value,omitempty"`
+}
+
+func (x *RuneStat) Reset() {
+	*x = RuneStat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuneStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuneStat) ProtoMessage() {}
+
+func (x *RuneStat) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuneStat.ProtoReflect.Descriptor instead.
+func (*RuneStat) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RuneStat) GetEffectType() int64 {
+	if x != nil {
+		return x.EffectType
+	}
+	return 0
+}
+
+func (x *RuneStat) GetEffectValue() int64 {
+	if x != nil {
+		return x.EffectValue
+	}
+	return 0
+}
+
+func (x *RuneStat) GetIsEnchanted() bool {
+	if x != nil {
+		return x.IsEnchanted
+	}
+	return false
+}
+
+func (x *RuneStat) GetGrindValue() int64 {
+	if x != nil {
+		return x.GrindValue
+	}
+	return 0
+}
+
+type Artifact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArtifactId      int64             `protobuf:"varint,1,opt,name=artifact_id,json=rid,proto3" json:"artifact_id,omitempty"`
+	WizardId        int64             `protobuf:"varint,2,opt,name=wizard_id,proto3" json:"wizard_id,omitempty"`
+	OccupiedId      int64             `protobuf:"varint,3,opt,name=occupied_id,proto3" json:"occupied_id,omitempty"`
+	Slot            int64             `protobuf:"varint,4,opt,name=slot,proto3" json:"slot,omitempty"`
+	Type            int64             `protobuf:"varint,5,opt,name=type,proto3" json:"type,omitempty"`
+	Attribute       int64             `protobuf:"varint,6,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Archetype       int64             `protobuf:"varint,7,opt,name=archetype,json=unit_style,proto3" json:"archetype,omitempty"`
+	Level           int64             `protobuf:"varint,8,opt,name=level,proto3" json:"level,omitempty"`
+	Quality         int64             `protobuf:"varint,9,opt,name=quality,json=rank,proto3" json:"quality,omitempty"`
+	OriginalQuality int64             `protobuf:"varint,10,opt,name=original_quality,json=natural_rank,proto3" json:"original_quality,omitempty"`
+	MainStat        *ArtifactEffect   `protobuf:"bytes,11,opt,name=main_stat,json=pri_effect,proto3" json:"main_stat,omitempty"`
+	Substats        []*ArtifactEffect `protobuf:"bytes,12,rep,name=substats,json=sec_effects,proto3" json:"substats,omitempty"`
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Artifact) GetArtifactId() int64 {
+	if x != nil {
+		return x.ArtifactId
+	}
+	return 0
+}
+
+func (x *Artifact) GetWizardId() int64 {
+	if x != nil {
+		return x.WizardId
+	}
+	return 0
+}
+
+func (x *Artifact) GetOccupiedId() int64 {
+	if x != nil {
+		return x.OccupiedId
+	}
+	return 0
+}
+
+func (x *Artifact) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *Artifact) GetType() int64 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Artifact) GetAttribute() int64 {
+	if x != nil {
+		return x.Attribute
+	}
+	return 0
+}
+
+func (x *Artifact) GetArchetype() int64 {
+	if x != nil {
+		return x.Archetype
+	}
+	return 0
+}
+
+func (x *Artifact) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Artifact) GetQuality() int64 {
+	if x != nil {
+		return x.Quality
+	}
+	return 0
+}
+
+func (x *Artifact) GetOriginalQuality() int64 {
+	if x != nil {
+		return x.OriginalQuality
+	}
+	return 0
+}
+
+func (x *Artifact) GetMainStat() *ArtifactEffect {
+	if x != nil {
+		return x.MainStat
+	}
+	return nil
+}
+
+func (x *Artifact) GetSubstats() []*ArtifactEffect {
+	if x != nil {
+		return x.Substats
+	}
+	return nil
+}
+
+type ArtifactEffect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EffectId int64   `protobuf:"varint,1,opt,name=effect_id,proto3" json:"effect_id,omitempty"`
+	Value    float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Upgrades int64   `protobuf:"varint,3,opt,name=upgrades,proto3" json:"upgrades,omitempty"`
+}
+
+func (x *ArtifactEffect) Reset() {
+	*x = ArtifactEffect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArtifactEffect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactEffect) ProtoMessage() {}
+
+func (x *ArtifactEffect) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactEffect.ProtoReflect.Descriptor instead.
+func (*ArtifactEffect) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ArtifactEffect) GetEffectId() int64 {
+	if x != nil {
+		return x.EffectId
+	}
+	return 0
+}
+
+func (x *ArtifactEffect) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ArtifactEffect) GetUpgrades() int64 {
+	if x != nil {
+		return x.Upgrades
+	}
+	return 0
+}
+
+type Building struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildingId       int64   `protobuf:"varint,1,opt,name=building_id,proto3" json:"building_id,omitempty"`
+	WizardId         int64   `protobuf:"varint,2,opt,name=wizard_id,proto3" json:"wizard_id,omitempty"`
+	IslandId         int64   `protobuf:"varint,3,opt,name=island_id,proto3" json:"island_id,omitempty"`
+	BuildingMasterId int64   `protobuf:"varint,4,opt,name=building_master_id,proto3" json:"building_master_id,omitempty"`
+	PosX             int64   `protobuf:"varint,5,opt,name=pos_x,proto3" json:"pos_x,omitempty"`
+	PosY             int64   `protobuf:"varint,6,opt,name=pos_y,proto3" json:"pos_y,omitempty"`
+	GainPerHour      float32 `protobuf:"fixed32,7,opt,name=gain_per_hour,proto3" json:"gain_per_hour,omitempty"`
+	HarvestMax       int64   `protobuf:"varint,8,opt,name=harvest_max,proto3" json:"harvest_max,omitempty"`
+	HarvestAvailable int64   `protobuf:"varint,9,opt,name=harvest_available,proto3" json:"harvest_available,omitempty"`
+	NextHarvest      int64   `protobuf:"varint,10,opt,name=next_harvest,proto3" json:"next_harvest,omitempty"`
+}
+
+func (x *Building) Reset() {
+	*x = Building{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Building) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Building) ProtoMessage() {}
+
+func (x *Building) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Building.ProtoReflect.Descriptor instead.
+func (*Building) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Building) GetBuildingId() int64 {
+	if x != nil {
+		return x.BuildingId
+	}
+	return 0
+}
+
+func (x *Building) GetWizardId() int64 {
+	if x != nil {
+		return x.WizardId
+	}
+	return 0
+}
+
+func (x *Building) GetIslandId() int64 {
+	if x != nil {
+		return x.IslandId
+	}
+	return 0
+}
+
+func (x *Building) GetBuildingMasterId() int64 {
+	if x != nil {
+		return x.BuildingMasterId
+	}
+	return 0
+}
+
+func (x *Building) GetPosX() int64 {
+	if x != nil {
+		return x.PosX
+	}
+	return 0
+}
+
+func (x *Building) GetPosY() int64 {
+	if x != nil {
+		return x.PosY
+	}
+	return 0
+}
+
+func (x *Building) GetGainPerHour() float32 {
+	if x != nil {
+		return x.GainPerHour
+	}
+	return 0
+}
+
+func (x *Building) GetHarvestMax() int64 {
+	if x != nil {
+		return x.HarvestMax
+	}
+	return 0
+}
+
+func (x *Building) GetHarvestAvailable() int64 {
+	if x != nil {
+		return x.HarvestAvailable
+	}
+	return 0
+}
+
+func (x *Building) GetNextHarvest() int64 {
+	if x != nil {
+		return x.NextHarvest
+	}
+	return 0
+}
+
+type HomunculusSkill struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnitId     int64 `protobuf:"varint,1,opt,name=unit_id,proto3" json:"unit_id,omitempty"`
+	SkillId    int64 `protobuf:"varint,2,opt,name=skill_id,proto3" json:"skill_id,omitempty"`
+	SkillDepth int64 `protobuf:"varint,3,opt,name=skill_depth,proto3" json:"skill_depth,omitempty"`
+	Level      int64 `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *HomunculusSkill) Reset() {
+	*x = HomunculusSkill{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HomunculusSkill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HomunculusSkill) ProtoMessage() {}
+
+func (x *HomunculusSkill) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HomunculusSkill.ProtoReflect.Descriptor instead.
+func (*HomunculusSkill) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HomunculusSkill) GetUnitId() int64 {
+	if x != nil {
+		return x.UnitId
+	}
+	return 0
+}
+
+func (x *HomunculusSkill) GetSkillId() int64 {
+	if x != nil {
+		return x.SkillId
+	}
+	return 0
+}
+
+func (x *HomunculusSkill) GetSkillDepth() int64 {
+	if x != nil {
+		return x.SkillDepth
+	}
+	return 0
+}
+
+func (x *HomunculusSkill) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type GetWizardInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command     string      `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	RetCode     int64       `protobuf:"varint,2,opt,name=ret_code,proto3" json:"ret_code,omitempty"`
+	TsVal       int64       `protobuf:"varint,3,opt,name=ts_val,proto3" json:"ts_val,omitempty"`
+	TValue      int64       `protobuf:"varint,4,opt,name=t_value,json=tvalue,proto3" json:"t_value,omitempty"`
+	TValueLocal int64       `protobuf:"varint,5,opt,name=t_value_local,json=tvaluelocal,proto3" json:"t_value_local,omitempty"`
+	TZone       string      `protobuf:"bytes,6,opt,name=t_zone,json=tzone,proto3" json:"t_zone,omitempty"`
+	WizardInfo  *WizardInfo `protobuf:"bytes,7,opt,name=wizard_info,proto3" json:"wizard_info,omitempty"`
+}
+
+func (x *GetWizardInfo) Reset() {
+	*x = GetWizardInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWizardInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWizardInfo) ProtoMessage() {}
+
+func (x *GetWizardInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWizardInfo.ProtoReflect.Descriptor instead.
+func (*GetWizardInfo) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetWizardInfo) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *GetWizardInfo) GetRetCode() int64 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *GetWizardInfo) GetTsVal() int64 {
+	if x != nil {
+		return x.TsVal
+	}
+	return 0
+}
+
+func (x *GetWizardInfo) GetTValue() int64 {
+	if x != nil {
+		return x.TValue
+	}
+	return 0
+}
+
+func (x *GetWizardInfo) GetTValueLocal() int64 {
+	if x != nil {
+		return x.TValueLocal
+	}
+	return 0
+}
+
+func (x *GetWizardInfo) GetTZone() string {
+	if x != nil {
+		return x.TZone
+	}
+	return ""
+}
+
+func (x *GetWizardInfo) GetWizardInfo() *WizardInfo {
+	if x != nil {
+		return x.WizardInfo
+	}
+	return nil
+}
+
+type BattleDungeonStart struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command     string      `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	RetCode     int64       `protobuf:"varint,2,opt,name=ret_code,proto3" json:"ret_code,omitempty"`
+	TsVal       int64       `protobuf:"varint,3,opt,name=ts_val,proto3" json:"ts_val,omitempty"`
+	TValue      int64       `protobuf:"varint,4,opt,name=t_value,json=tvalue,proto3" json:"t_value,omitempty"`
+	TValueLocal int64       `protobuf:"varint,5,opt,name=t_value_local,json=tvaluelocal,proto3" json:"t_value_local,omitempty"`
+	TZone       string      `protobuf:"bytes,6,opt,name=t_zone,json=tzone,proto3" json:"t_zone,omitempty"`
+	WizardInfo  *WizardInfo `protobuf:"bytes,7,opt,name=wizard_info,proto3" json:"wizard_info,omitempty"`
+	BattleKey   int64       `protobuf:"varint,8,opt,name=battle_key,proto3" json:"battle_key,omitempty"`
+}
+
+func (x *BattleDungeonStart) Reset() {
+	*x = BattleDungeonStart{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BattleDungeonStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleDungeonStart) ProtoMessage() {}
+
+func (x *BattleDungeonStart) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleDungeonStart.ProtoReflect.Descriptor instead.
+func (*BattleDungeonStart) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BattleDungeonStart) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *BattleDungeonStart) GetRetCode() int64 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *BattleDungeonStart) GetTsVal() int64 {
+	if x != nil {
+		return x.TsVal
+	}
+	return 0
+}
+
+func (x *BattleDungeonStart) GetTValue() int64 {
+	if x != nil {
+		return x.TValue
+	}
+	return 0
+}
+
+func (x *BattleDungeonStart) GetTValueLocal() int64 {
+	if x != nil {
+		return x.TValueLocal
+	}
+	return 0
+}
+
+func (x *BattleDungeonStart) GetTZone() string {
+	if x != nil {
+		return x.TZone
+	}
+	return ""
+}
+
+func (x *BattleDungeonStart) GetWizardInfo() *WizardInfo {
+	if x != nil {
+		return x.WizardInfo
+	}
+	return nil
+}
+
+func (x *BattleDungeonStart) GetBattleKey() int64 {
+	if x != nil {
+		return x.BattleKey
+	}
+	return 0
+}
+
+type BattleDungeonResultV2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command         string                         `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	RetCode         int64                          `protobuf:"varint,2,opt,name=ret_code,proto3" json:"ret_code,omitempty"`
+	TsVal           int64                          `protobuf:"varint,3,opt,name=ts_val,proto3" json:"ts_val,omitempty"`
+	TValue          int64                          `protobuf:"varint,4,opt,name=t_value,json=tvalue,proto3" json:"t_value,omitempty"`
+	TValueLocal     int64                          `protobuf:"varint,5,opt,name=t_value_local,json=tvaluelocal,proto3" json:"t_value_local,omitempty"`
+	TZone           string                         `protobuf:"bytes,6,opt,name=t_zone,json=tzone,proto3" json:"t_zone,omitempty"`
+	WinLose         int64                          `protobuf:"varint,7,opt,name=win_lose,proto3" json:"win_lose,omitempty"`
+	WizardInfo      *WizardInfo                    `protobuf:"bytes,8,opt,name=wizard_info,proto3" json:"wizard_info,omitempty"`
+	Reward          *DungeonReward                 `protobuf:"bytes,9,opt,name=reward,proto3" json:"reward,omitempty"`
+	ChangedItemList []*DungeonChangedItemListEntry `protobuf:"bytes,10,rep,name=changed_item_list,proto3" json:"changed_item_list,omitempty"`
+}
+
+func (x *BattleDungeonResultV2) Reset() {
+	*x = BattleDungeonResultV2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BattleDungeonResultV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleDungeonResultV2) ProtoMessage() {}
+
+func (x *BattleDungeonResultV2) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleDungeonResultV2.ProtoReflect.Descriptor instead.
+func (*BattleDungeonResultV2) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BattleDungeonResultV2) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *BattleDungeonResultV2) GetRetCode() int64 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *BattleDungeonResultV2) GetTsVal() int64 {
+	if x != nil {
+		return x.TsVal
+	}
+	return 0
+}
+
+func (x *BattleDungeonResultV2) GetTValue() int64 {
+	if x != nil {
+		return x.TValue
+	}
+	return 0
+}
+
+func (x *BattleDungeonResultV2) GetTValueLocal() int64 {
+	if x != nil {
+		return x.TValueLocal
+	}
+	return 0
+}
+
+func (x *BattleDungeonResultV2) GetTZone() string {
+	if x != nil {
+		return x.TZone
+	}
+	return ""
+}
+
+func (x *BattleDungeonResultV2) GetWinLose() int64 {
+	if x != nil {
+		return x.WinLose
+	}
+	return 0
+}
+
+func (x *BattleDungeonResultV2) GetWizardInfo() *WizardInfo {
+	if x != nil {
+		return x.WizardInfo
+	}
+	return nil
+}
+
+func (x *BattleDungeonResultV2) GetReward() *DungeonReward {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+func (x *BattleDungeonResultV2) GetChangedItemList() []*DungeonChangedItemListEntry {
+	if x != nil {
+		return x.ChangedItemList
+	}
+	return nil
+}
+
+type DungeonReward struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mana       int64          `protobuf:"varint,1,opt,name=mana,proto3" json:"mana,omitempty"`
+	Crystal    int64          `protobuf:"varint,2,opt,name=crystal,proto3" json:"crystal,omitempty"`
+	Energy     int64          `protobuf:"varint,3,opt,name=energy,proto3" json:"energy,omitempty"`
+	Crate      *_struct.Value `protobuf:"bytes,4,opt,name=crate,proto3" json:"crate,omitempty"`
+	EventCrate *_struct.Value `protobuf:"bytes,5,opt,name=event_crate,proto3" json:"event_crate,omitempty"`
+}
+
+func (x *DungeonReward) Reset() {
+	*x = DungeonReward{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DungeonReward) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DungeonReward) ProtoMessage() {}
+
+func (x *DungeonReward) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DungeonReward.ProtoReflect.Descriptor instead.
+func (*DungeonReward) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DungeonReward) GetMana() int64 {
+	if x != nil {
+		return x.Mana
+	}
+	return 0
+}
+
+func (x *DungeonReward) GetCrystal() int64 {
+	if x != nil {
+		return x.Crystal
+	}
+	return 0
+}
+
+func (x *DungeonReward) GetEnergy() int64 {
+	if x != nil {
+		return x.Energy
+	}
+	return 0
+}
+
+func (x *DungeonReward) GetCrate() *_struct.Value {
+	if x != nil {
+		return x.Crate
+	}
+	return nil
+}
+
+func (x *DungeonReward) GetEventCrate() *_struct.Value {
+	if x != nil {
+		return x.EventCrate
+	}
+	return nil
+}
+
+type DungeonChangedItemListEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type int64           `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Info *_struct.Struct `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	View *_struct.Struct `protobuf:"bytes,3,opt,name=view,proto3" json:"view,omitempty"`
+}
+
+func (x *DungeonChangedItemListEntry) Reset() {
+	*x = DungeonChangedItemListEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DungeonChangedItemListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DungeonChangedItemListEntry) ProtoMessage() {}
+
+func (x *DungeonChangedItemListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DungeonChangedItemListEntry.ProtoReflect.Descriptor instead.
+func (*DungeonChangedItemListEntry) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DungeonChangedItemListEntry) GetType() int64 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *DungeonChangedItemListEntry) GetInfo() *_struct.Struct {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *DungeonChangedItemListEntry) GetView() *_struct.Struct {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type BattleTrialTowerStartV2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command     string      `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	RetCode     int64       `protobuf:"varint,2,opt,name=ret_code,proto3" json:"ret_code,omitempty"`
+	TsVal       int64       `protobuf:"varint,3,opt,name=ts_val,proto3" json:"ts_val,omitempty"`
+	TValue      int64       `protobuf:"varint,4,opt,name=t_value,json=tvalue,proto3" json:"t_value,omitempty"`
+	TValueLocal int64       `protobuf:"varint,5,opt,name=t_value_local,json=tvaluelocal,proto3" json:"t_value_local,omitempty"`
+	TZone       string      `protobuf:"bytes,6,opt,name=t_zone,json=tzone,proto3" json:"t_zone,omitempty"`
+	WizardInfo  *WizardInfo `protobuf:"bytes,7,opt,name=wizard_info,proto3" json:"wizard_info,omitempty"`
+}
+
+func (x *BattleTrialTowerStartV2) Reset() {
+	*x = BattleTrialTowerStartV2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BattleTrialTowerStartV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleTrialTowerStartV2) ProtoMessage() {}
+
+func (x *BattleTrialTowerStartV2) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleTrialTowerStartV2.ProtoReflect.Descriptor instead.
+func (*BattleTrialTowerStartV2) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BattleTrialTowerStartV2) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *BattleTrialTowerStartV2) GetRetCode() int64 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerStartV2) GetTsVal() int64 {
+	if x != nil {
+		return x.TsVal
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerStartV2) GetTValue() int64 {
+	if x != nil {
+		return x.TValue
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerStartV2) GetTValueLocal() int64 {
+	if x != nil {
+		return x.TValueLocal
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerStartV2) GetTZone() string {
+	if x != nil {
+		return x.TZone
+	}
+	return ""
+}
+
+func (x *BattleTrialTowerStartV2) GetWizardInfo() *WizardInfo {
+	if x != nil {
+		return x.WizardInfo
+	}
+	return nil
+}
+
+type BattleTrialTowerResultV2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command     string         `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	RetCode     int64          `protobuf:"varint,2,opt,name=ret_code,proto3" json:"ret_code,omitempty"`
+	TsVal       int64          `protobuf:"varint,3,opt,name=ts_val,proto3" json:"ts_val,omitempty"`
+	TValue      int64          `protobuf:"varint,4,opt,name=t_value,json=tvalue,proto3" json:"t_value,omitempty"`
+	TValueLocal int64          `protobuf:"varint,5,opt,name=t_value_local,json=tvaluelocal,proto3" json:"t_value_local,omitempty"`
+	TZone       string         `protobuf:"bytes,6,opt,name=t_zone,json=tzone,proto3" json:"t_zone,omitempty"`
+	WinLose     int64          `protobuf:"varint,7,opt,name=win_lose,proto3" json:"win_lose,omitempty"`
+	WizardInfo  *WizardInfo    `protobuf:"bytes,8,opt,name=wizard_info,proto3" json:"wizard_info,omitempty"`
+	Reward      *DungeonReward `protobuf:"bytes,9,opt,name=reward,proto3" json:"reward,omitempty"`
+	FloorId     int64          `protobuf:"varint,10,opt,name=floor_id,proto3" json:"floor_id,omitempty"`
+}
+
+func (x *BattleTrialTowerResultV2) Reset() {
+	*x = BattleTrialTowerResultV2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gamemodels_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BattleTrialTowerResultV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleTrialTowerResultV2) ProtoMessage() {}
+
+func (x *BattleTrialTowerResultV2) ProtoReflect() protoreflect.Message {
+	mi := &file_gamemodels_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleTrialTowerResultV2.ProtoReflect.Descriptor instead.
+func (*BattleTrialTowerResultV2) Descriptor() ([]byte, []int) {
+	return file_gamemodels_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BattleTrialTowerResultV2) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *BattleTrialTowerResultV2) GetRetCode() int64 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerResultV2) GetTsVal() int64 {
+	if x != nil {
+		return x.TsVal
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerResultV2) GetTValue() int64 {
+	if x != nil {
+		return x.TValue
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerResultV2) GetTValueLocal() int64 {
+	if x != nil {
+		return x.TValueLocal
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerResultV2) GetTZone() string {
+	if x != nil {
+		return x.TZone
+	}
+	return ""
+}
+
+func (x *BattleTrialTowerResultV2) GetWinLose() int64 {
+	if x != nil {
+		return x.WinLose
+	}
+	return 0
+}
+
+func (x *BattleTrialTowerResultV2) GetWizardInfo() *WizardInfo {
+	if x != nil {
+		return x.WizardInfo
+	}
+	return nil
+}
+
+func (x *BattleTrialTowerResultV2) GetReward() *DungeonReward {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+func (x *BattleTrialTowerResultV2) GetFloorId() int64 {
+	if x != nil {
+		return x.FloorId
+	}
+	return 0
+}
+
+var File_gamemodels_proto protoreflect.FileDescriptor
+
+var file_gamemodels_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x11, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xaf, 0x04, 0x0a, 0x0c, 0x48, 0x75, 0x62, 0x55, 0x73, 0x65, 0x72, 0x4c,
+	0x6f, 0x67, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x73,
+	0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x73, 0x5f, 0x76,
+	0x61, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x74,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12,
+	0x15, 0x0a, 0x06, 0x74, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64,
+	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x77,
+	0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e,
+	0x57, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x77, 0x69, 0x7a, 0x61,
+	0x72, 0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x35, 0x0a, 0x09, 0x75, 0x6e, 0x69, 0x74, 0x5f,
+	0x6c, 0x69, 0x73, 0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x77, 0x61,
+	0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55,
+	0x6e, 0x69, 0x74, 0x52, 0x09, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x2d,
+	0x0a, 0x05, 0x72, 0x75, 0x6e, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x52, 0x75, 0x6e, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x65, 0x73, 0x12, 0x39, 0x0a,
+	0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x41, 0x0a, 0x0d, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x58, 0x0a, 0x15, 0x68,
+	0x6f, 0x6d, 0x75, 0x6e, 0x63, 0x75, 0x6c, 0x75, 0x73, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x5f,
+	0x6c, 0x69, 0x73, 0x74, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x77, 0x61,
+	0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x48,
+	0x6f, 0x6d, 0x75, 0x6e, 0x63, 0x75, 0x6c, 0x75, 0x73, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x15,
+	0x68, 0x6f, 0x6d, 0x75, 0x6e, 0x63, 0x75, 0x6c, 0x75, 0x73, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c,
+	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x22, 0xfa, 0x03, 0x0a, 0x0a, 0x57, 0x69, 0x7a, 0x61, 0x72, 0x64,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f,
+	0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x6d,
+	0x61, 0x6e, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72,
+	0x64, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x12, 0x26, 0x0a, 0x0e, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64,
+	0x5f, 0x63, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x63, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x12, 0x22,
+	0x0a, 0x0c, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x24, 0x0a, 0x0d, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x65, 0x6e, 0x65,
+	0x72, 0x67, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x77, 0x69, 0x7a, 0x61, 0x72,
+	0x64, 0x5f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x6e, 0x65, 0x72,
+	0x67, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x6e,
+	0x65, 0x72, 0x67, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x6e, 0x65, 0x72,
+	0x67, 0x79, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x0e, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x6e,
+	0x12, 0x2a, 0x0a, 0x10, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f,
+	0x67, 0x61, 0x69, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x67, 0x61, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x76, 0x70, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x70, 0x76, 0x70, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61,
+	0x69, 0x6c, 0x5f, 0x62, 0x6f, 0x78, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x62, 0x6f, 0x78, 0x5f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x14, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x6c,
+	0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x6d,
+	0x61, 0x78, 0x22, 0xeb, 0x04, 0x0a, 0x04, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75,
+	0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x6e,
+	0x69, 0x74, 0x5f, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64,
+	0x5f, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x74,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x75, 0x6e, 0x69,
+	0x74, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x74,
+	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x63, 0x6f, 0x6e, 0x12, 0x10,
+	0x0a, 0x03, 0x61, 0x74, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x74, 0x6b,
+	0x12, 0x10, 0x0a, 0x03, 0x64, 0x65, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x64,
+	0x65, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x70, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x73, 0x70, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x69, 0x73, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x65, 0x73, 0x69, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x72, 0x69, 0x74,
+	0x69, 0x63, 0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x12, 0x28,
+	0x0a, 0x0f, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x64, 0x61, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61,
+	0x6c, 0x5f, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x6f, 0x6d, 0x75, 0x6e, 0x63,
+	0x75, 0x6c, 0x75, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x68, 0x6f, 0x6d, 0x75,
+	0x6e, 0x63, 0x75, 0x6c, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x68, 0x6f, 0x6d, 0x75, 0x6e, 0x63,
+	0x75, 0x6c, 0x75, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x68, 0x6f, 0x6d, 0x75, 0x6e, 0x63, 0x75, 0x6c, 0x75, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x34, 0x0a, 0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x06,
+	0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x72, 0x75, 0x6e, 0x65, 0x73, 0x18,
+	0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x65, 0x52, 0x05,
+	0x72, 0x75, 0x6e, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70,
+	0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x41, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73,
+	0x22, 0x3d, 0x0a, 0x09, 0x55, 0x6e, 0x69, 0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22,
+	0x8b, 0x04, 0x0a, 0x04, 0x52, 0x75, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x65, 0x5f,
+	0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64,
+	0x12, 0x24, 0x0a, 0x0d, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x69, 0x65, 0x64, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x69, 0x65,
+	0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x69,
+	0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6f, 0x63, 0x63,
+	0x75, 0x70, 0x69, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x08, 0x72, 0x75, 0x6e, 0x65,
+	0x5f, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x73, 0x65, 0x74, 0x5f,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x5f, 0x63, 0x75, 0x72, 0x72, 0x12, 0x15, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x6c, 0x6f, 0x74, 0x5f, 0x6e, 0x6f, 0x12, 0x15, 0x0a, 0x07,
+	0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x12, 0x1f, 0x0a, 0x10, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f,
+	0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65,
+	0x78, 0x74, 0x72, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6e, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6e, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x73, 0x65, 0x6c, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x37,
+	0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x52, 0x07,
+	0x70, 0x72, 0x69, 0x5f, 0x65, 0x66, 0x66, 0x12, 0x3c, 0x0a, 0x0b, 0x69, 0x6e, 0x6e, 0x61, 0x74,
+	0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73,
+	0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x2e, 0x52, 0x75, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x5f, 0x65, 0x66, 0x66, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x52, 0x07, 0x73, 0x65, 0x63, 0x5f, 0x65, 0x66, 0x66, 0x22, 0x96, 0x01,
+	0x0a, 0x08, 0x52, 0x75, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x66,
+	0x66, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x65, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x5f, 0x65, 0x6e, 0x63, 0x68, 0x61,
+	0x6e, 0x74, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x67, 0x72, 0x69, 0x6e, 0x64, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x67, 0x72, 0x69, 0x6e, 0x64,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xa2, 0x03, 0x0a, 0x08, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x72, 0x69, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6f,
+	0x63, 0x63, 0x75, 0x70, 0x69, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x69, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x6c, 0x6f,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x09, 0x61, 0x72, 0x63, 0x68, 0x65, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x73, 0x74, 0x79,
+	0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x15, 0x0a, 0x07, 0x71, 0x75, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12,
+	0x26, 0x0a, 0x10, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x71, 0x75, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x61, 0x6c, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x40, 0x0a, 0x09, 0x6d, 0x61, 0x69, 0x6e, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x77, 0x61,
+	0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x52, 0x0a, 0x70,
+	0x72, 0x69, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x40, 0x0a, 0x08, 0x73, 0x75, 0x62,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x77,
+	0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e,
+	0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x52, 0x0b,
+	0x73, 0x65, 0x63, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x22, 0x60, 0x0a, 0x0e, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x73, 0x22, 0xde, 0x02,
+	0x0a, 0x08, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73,
+	0x6c, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x69,
+	0x73, 0x6c, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x12, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x73, 0x5f,
+	0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x6f, 0x73, 0x5f, 0x78, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x6f, 0x73, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70,
+	0x6f, 0x73, 0x5f, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x67, 0x61, 0x69, 0x6e, 0x5f, 0x70, 0x65, 0x72,
+	0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x67, 0x61, 0x69,
+	0x6e, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x61,
+	0x72, 0x76, 0x65, 0x73, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x68, 0x61, 0x72, 0x76, 0x65, 0x73, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x12, 0x2c, 0x0a, 0x11,
+	0x68, 0x61, 0x72, 0x76, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x68, 0x61, 0x72, 0x76, 0x65, 0x73, 0x74,
+	0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x68, 0x61, 0x72, 0x76, 0x65, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68, 0x61, 0x72, 0x76, 0x65, 0x73, 0x74, 0x22, 0x7f,
+	0x0a, 0x0f, 0x48, 0x6f, 0x6d, 0x75, 0x6e, 0x63, 0x75, 0x6c, 0x75, 0x73, 0x53, 0x6b, 0x69, 0x6c,
+	0x6c, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73,
+	0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6b, 0x69, 0x6c, 0x6c,
+	0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x6b,
+	0x69, 0x6c, 0x6c, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22,
+	0xf2, 0x01, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x57, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72,
+	0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x73, 0x5f, 0x76, 0x61,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x12,
+	0x17, 0x0a, 0x07, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x15, 0x0a, 0x06,
+	0x74, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x7a,
+	0x6f, 0x6e, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70,
+	0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x57, 0x69, 0x7a,
+	0x61, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x22, 0x97, 0x02, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x44,
+	0x75, 0x6e, 0x67, 0x65, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x5f, 0x7a, 0x6f, 0x6e, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x3f, 0x0a,
+	0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x57, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x1e,
+	0x0a, 0x0a, 0x62, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x62, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x22, 0xae,
+	0x03, 0x0a, 0x15, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x44, 0x75, 0x6e, 0x67, 0x65, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x56, 0x32, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x22, 0x0a, 0x0d, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x69,
+	0x6e, 0x5f, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x77, 0x69,
+	0x6e, 0x5f, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64,
+	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x77,
+	0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e,
+	0x57, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x77, 0x69, 0x7a, 0x61,
+	0x72, 0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x38, 0x0a, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x44, 0x75, 0x6e, 0x67,
+	0x65, 0x6f, 0x6e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x12, 0x5c, 0x0a, 0x11, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x69, 0x74, 0x65,
+	0x6d, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x73,
+	0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x2e, 0x44, 0x75, 0x6e, 0x67, 0x65, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x49,
+	0x74, 0x65, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x22,
+	0xbd, 0x01, 0x0a, 0x0d, 0x44, 0x75, 0x6e, 0x67, 0x65, 0x6f, 0x6e, 0x52, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x6e, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x6d, 0x61, 0x6e, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x63, 0x72, 0x61, 0x74, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
+	0x63, 0x72, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x63,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x72, 0x61, 0x74, 0x65, 0x22,
+	0x8b, 0x01, 0x0a, 0x1b, 0x44, 0x75, 0x6e, 0x67, 0x65, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x12, 0x2b, 0x0a, 0x04, 0x76, 0x69, 0x65, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x22, 0xfc, 0x01,
+	0x0a, 0x17, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x54, 0x72, 0x69, 0x61, 0x6c, 0x54, 0x6f, 0x77,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74, 0x56, 0x32, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x22, 0x0a, 0x0d, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x77,
+	0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x57, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0xef, 0x02, 0x0a,
+	0x18, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x54, 0x72, 0x69, 0x61, 0x6c, 0x54, 0x6f, 0x77, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x56, 0x32, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x74, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x22, 0x0a, 0x0d, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x77,
+	0x69, 0x6e, 0x5f, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x77,
+	0x69, 0x6e, 0x5f, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x77, 0x69, 0x7a, 0x61, 0x72,
+	0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73,
+	0x77, 0x61, 0x72, 0x70, 0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x2e, 0x57, 0x69, 0x7a, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x77, 0x69, 0x7a,
+	0x61, 0x72, 0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x38, 0x0a, 0x06, 0x72, 0x65, 0x77, 0x61,
+	0x72, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x77, 0x61, 0x72, 0x70,
+	0x66, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x44, 0x75, 0x6e,
+	0x67, 0x65, 0x6f, 0x6e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x77, 0x61,
+	0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6c, 0x6f, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x42, 0x35,
+	0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x77, 0x61,
+	0x72, 0x70, 0x66, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x61,
+	0x6d, 0x65, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gamemodels_proto_rawDescOnce sync.Once
+	file_gamemodels_proto_rawDescData = file_gamemodels_proto_rawDesc
+)
+
+func file_gamemodels_proto_rawDescGZIP() []byte {
+	file_gamemodels_proto_rawDescOnce.Do(func() {
+		file_gamemodels_proto_rawDescData = protoimpl.X.CompressGZIP(file_gamemodels_proto_rawDescData)
+	})
+	return file_gamemodels_proto_rawDescData
+}
+
+var file_gamemodels_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_gamemodels_proto_goTypes = []interface{}{
+	(*HubUserLogin)(nil),                // 0: swarpf.gamemodels.HubUserLogin
+	(*WizardInfo)(nil),                  // 1: swarpf.gamemodels.WizardInfo
+	(*Unit)(nil),                        // 2: swarpf.gamemodels.Unit
+	(*UnitSkill)(nil),                   // 3: swarpf.gamemodels.UnitSkill
+	(*Rune)(nil),                        // 4: swarpf.gamemodels.Rune
+	(*RuneStat)(nil),                    // 5: swarpf.gamemodels.RuneStat
+	(*Artifact)(nil),                    // 6: swarpf.gamemodels.Artifact
+	(*ArtifactEffect)(nil),              // 7: swarpf.gamemodels.ArtifactEffect
+	(*Building)(nil),                    // 8: swarpf.gamemodels.Building
+	(*HomunculusSkill)(nil),             // 9: swarpf.gamemodels.HomunculusSkill
+	(*GetWizardInfo)(nil),               // 10: swarpf.gamemodels.GetWizardInfo
+	(*BattleDungeonStart)(nil),          // 11: swarpf.gamemodels.BattleDungeonStart
+	(*BattleDungeonResultV2)(nil),       // 12: swarpf.gamemodels.BattleDungeonResultV2
+	(*DungeonReward)(nil),               // 13: swarpf.gamemodels.DungeonReward
+	(*DungeonChangedItemListEntry)(nil), // 14: swarpf.gamemodels.DungeonChangedItemListEntry
+	(*BattleTrialTowerStartV2)(nil),     // 15: swarpf.gamemodels.BattleTrialTowerStartV2
+	(*BattleTrialTowerResultV2)(nil),    // 16: swarpf.gamemodels.BattleTrialTowerResultV2
+	(*_struct.Value)(nil),               // 17: google.protobuf.Value
+	(*_struct.Struct)(nil),              // 18: google.protobuf.Struct
+}
+var file_gamemodels_proto_depIdxs = []int32{
+	1,  // 0: swarpf.gamemodels.HubUserLogin.wizard_info:type_name -> swarpf.gamemodels.WizardInfo
+	2,  // 1: swarpf.gamemodels.HubUserLogin.unit_list:type_name -> swarpf.gamemodels.Unit
+	4,  // 2: swarpf.gamemodels.HubUserLogin.runes:type_name -> swarpf.gamemodels.Rune
+	6,  // 3: swarpf.gamemodels.HubUserLogin.artifacts:type_name -> swarpf.gamemodels.Artifact
+	8,  // 4: swarpf.gamemodels.HubUserLogin.building_list:type_name -> swarpf.gamemodels.Building
+	9,  // 5: swarpf.gamemodels.HubUserLogin.homunculus_skill_list:type_name -> swarpf.gamemodels.HomunculusSkill
+	3,  // 6: swarpf.gamemodels.Unit.skills:type_name -> swarpf.gamemodels.UnitSkill
+	4,  // 7: swarpf.gamemodels.Unit.runes:type_name -> swarpf.gamemodels.Rune
+	6,  // 8: swarpf.gamemodels.Unit.artifacts:type_name -> swarpf.gamemodels.Artifact
+	5,  // 9: swarpf.gamemodels.Rune.main_stat:type_name -> swarpf.gamemodels.RuneStat
+	5,  // 10: swarpf.gamemodels.Rune.innate_stat:type_name -> swarpf.gamemodels.RuneStat
+	5,  // 11: swarpf.gamemodels.Rune.substats:type_name -> swarpf.gamemodels.RuneStat
+	7,  // 12: swarpf.gamemodels.Artifact.main_stat:type_name -> swarpf.gamemodels.ArtifactEffect
+	7,  // 13: swarpf.gamemodels.Artifact.substats:type_name -> swarpf.gamemodels.ArtifactEffect
+	1,  // 14: swarpf.gamemodels.GetWizardInfo.wizard_info:type_name -> swarpf.gamemodels.WizardInfo
+	1,  // 15: swarpf.gamemodels.BattleDungeonStart.wizard_info:type_name -> swarpf.gamemodels.WizardInfo
+	1,  // 16: swarpf.gamemodels.BattleDungeonResultV2.wizard_info:type_name -> swarpf.gamemodels.WizardInfo
+	13, // 17: swarpf.gamemodels.BattleDungeonResultV2.reward:type_name -> swarpf.gamemodels.DungeonReward
+	14, // 18: swarpf.gamemodels.BattleDungeonResultV2.changed_item_list:type_name -> swarpf.gamemodels.DungeonChangedItemListEntry
+	17, // 19: swarpf.gamemodels.DungeonReward.crate:type_name -> google.protobuf.Value
+	17, // 20: swarpf.gamemodels.DungeonReward.event_crate:type_name -> google.protobuf.Value
+	18, // 21: swarpf.gamemodels.DungeonChangedItemListEntry.info:type_name -> google.protobuf.Struct
+	18, // 22: swarpf.gamemodels.DungeonChangedItemListEntry.view:type_name -> google.protobuf.Struct
+	1,  // 23: swarpf.gamemodels.BattleTrialTowerStartV2.wizard_info:type_name -> swarpf.gamemodels.WizardInfo
+	1,  // 24: swarpf.gamemodels.BattleTrialTowerResultV2.wizard_info:type_name -> swarpf.gamemodels.WizardInfo
+	13, // 25: swarpf.gamemodels.BattleTrialTowerResultV2.reward:type_name -> swarpf.gamemodels.DungeonReward
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_gamemodels_proto_init() }
+func file_gamemodels_proto_init() {
+	if File_gamemodels_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gamemodels_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HubUserLogin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WizardInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Unit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnitSkill); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rune); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuneStat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Artifact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArtifactEffect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Building); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HomunculusSkill); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWizardInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BattleDungeonStart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BattleDungeonResultV2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DungeonReward); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DungeonChangedItemListEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BattleTrialTowerStartV2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gamemodels_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BattleTrialTowerResultV2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gamemodels_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gamemodels_proto_goTypes,
+		DependencyIndexes: file_gamemodels_proto_depIdxs,
+		MessageInfos:      file_gamemodels_proto_msgTypes,
+	}.Build()
+	File_gamemodels_proto = out.File
+	file_gamemodels_proto_rawDesc = nil
+	file_gamemodels_proto_goTypes = nil
+	file_gamemodels_proto_depIdxs = nil
+}
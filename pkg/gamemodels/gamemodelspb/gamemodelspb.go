@@ -0,0 +1,42 @@
+// Package gamemodelspb has the protobuf messages of the responses of the well-known commands in gamemodels, see
+// gamemodels.CommandToType. The messages are generated from the gamemodels types, the field names follow the Go
+// field names and the JSON names are the names of the game api.
+package gamemodelspb
+
+//go:generate go run gen.go
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// NewResponse decodes the JSON response of a well-known command into its message. It returns nil without an error
+// for other commands.
+func NewResponse(command, response string) (proto.Message, error) {
+	newMessage, ok := responseMessages[command]
+	if !ok {
+		return nil, nil
+	}
+
+	// decode with gamemodels first, it normalises the encodings of the game api like the array encoded rune stats
+	decoded := reflect.New(gamemodels.CommandToType(command)).Interface()
+	if err := json.Unmarshal([]byte(response), decoded); err != nil {
+		return nil, fmt.Errorf("could not decode %s response: %w", command, err)
+	}
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	message := newMessage()
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+		return nil, fmt.Errorf("could not convert %s response: %w", command, err)
+	}
+	return message, nil
+}
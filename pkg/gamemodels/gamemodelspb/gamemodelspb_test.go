@@ -0,0 +1,65 @@
+package gamemodelspb
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewResponse(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("..", "testdata", "HubUserLogin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := NewResponse("HubUserLogin", string(data))
+	if err != nil {
+		t.Fatalf("NewResponse() error = %v", err)
+	}
+	login, ok := message.(*HubUserLogin)
+	if !ok {
+		t.Fatalf("NewResponse() = %T, want *HubUserLogin", message)
+	}
+
+	if login.GetWizardInfo().GetWizardId() != 12345678 || login.GetTZone() != "America/Los_Angeles" {
+		t.Errorf("wizard info = %v, tzone = %q", login.GetWizardInfo(), login.GetTZone())
+	}
+	if len(login.GetUnitList()) != 2 {
+		t.Fatalf("login has %d units, want 2", len(login.GetUnitList()))
+	}
+
+	// the runes are ordered by slot and the ancient rune has its stars without the 10 additional stars of the game
+	runes := login.GetUnitList()[0].GetRunes()
+	if len(runes) != 2 || runes[0].GetSlot() != 1 || runes[1].GetSlot() != 2 {
+		t.Fatalf("runes = %v, want the runes of slot 1 and 2", runes)
+	}
+	if !runes[0].GetAncient() || runes[0].GetStars() != 6 {
+		t.Errorf("rune %d: ancient = %v, stars = %d, want an ancient 6 star rune",
+			runes[0].GetRuneId(), runes[0].GetAncient(), runes[0].GetStars())
+	}
+	wantSubstat := &RuneStat{EffectType: 9, EffectValue: 5, IsEnchanted: true}
+	if substats := runes[1].GetSubstats(); len(substats) != 4 || !proto.Equal(substats[1], wantSubstat) {
+		t.Errorf("substats = %v, want %v as second substat", substats, wantSubstat)
+	}
+	if runes[1].GetInnateStat() != nil {
+		t.Errorf("innate stat = %v, want none", runes[1].GetInnateStat())
+	}
+
+	artifacts := login.GetUnitList()[1].GetArtifacts()
+	if len(artifacts) != 1 || artifacts[0].GetArtifactId() != 700000002 || artifacts[0].GetArchetype() != 3 {
+		t.Errorf("artifacts = %v, want artifact 700000002", artifacts)
+	}
+}
+
+func TestNewResponseOtherCommand(t *testing.T) {
+	message, err := NewResponse("GetNoticeChat", `{"command":"GetNoticeChat"}`)
+	if message != nil || err != nil {
+		t.Errorf("NewResponse() = %v, %v, want no message", message, err)
+	}
+
+	if _, err := NewResponse("GetWizardInfo", "{"); err == nil {
+		t.Error("NewResponse() of an invalid response returned no error")
+	}
+}
@@ -0,0 +1,257 @@
+//go:build ignore
+// +build ignore
+
+// gen generates the protobuf messages of the well-known commands from the response types of gamemodels. It writes
+// gamemodels.proto, compiles it with protoc-gen-go and writes the map of the commands to their messages.
+//
+// Field numbers follow the order of the struct fields, new fields have to be added at the end of the structs.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// commands are the well-known commands, see gamemodels.CommandToType
+var commands = []string{
+	"HubUserLogin",
+	"GetWizardInfo",
+	"BattleDungeonStart",
+	"BattleDungeonResult_V2",
+	"BattleTrialTowerStart_v2",
+	"BattleTrialTowerResult_v2",
+}
+
+const (
+	protoPackage = "swarpf.gamemodels"
+	protoFile    = "gamemodels.proto"
+	goPackage    = "github.com/swarpf/proxy/pkg/gamemodels/gamemodelspb"
+	structProto  = "google/protobuf/struct.proto"
+)
+
+type generator struct {
+	file     *descriptorpb.FileDescriptorProto
+	messages map[reflect.Type]string
+}
+
+func main() {
+	g := &generator{
+		file: &descriptorpb.FileDescriptorProto{
+			Name:       proto.String(protoFile),
+			Package:    proto.String(protoPackage),
+			Dependency: []string{structProto},
+			Syntax:     proto.String("proto3"),
+			Options:    &descriptorpb.FileOptions{GoPackage: proto.String(goPackage)},
+		},
+		messages: map[reflect.Type]string{},
+	}
+
+	responses := map[string]string{}
+	for _, command := range commands {
+		t := gamemodels.CommandToType(command)
+		if t == nil {
+			log.Fatalf("%s is not a well-known command", command)
+		}
+		g.message(t)
+		responses[command] = t.Name()
+	}
+
+	writeFile(protoFile, g.protoSource())
+	for _, file := range g.compile() {
+		writeFile(file.GetName(), []byte(file.GetContent()))
+	}
+	writeFile("commands.go", commandsSource(responses))
+}
+
+// message adds the message of the struct type t and returns its full name
+func (g *generator) message(t reflect.Type) string {
+	if name, ok := g.messages[t]; ok {
+		return name
+	}
+
+	name := "." + protoPackage + "." + t.Name()
+	g.messages[t] = name
+
+	message := &descriptorpb.DescriptorProto{Name: proto.String(t.Name())}
+	g.file.MessageType = append(g.file.MessageType, message)
+	g.fields(message, t)
+
+	return name
+}
+
+func (g *generator) fields(message *descriptorpb.DescriptorProto, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			g.fields(message, f.Type)
+			continue
+		}
+
+		jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.PkgPath != "" || jsonName == "" || jsonName == "-" {
+			continue
+		}
+
+		field := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(snakeCase(f.Name)),
+			JsonName: proto.String(jsonName),
+			Number:   proto.Int32(int32(len(message.Field) + 1)),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Slice {
+			field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		switch ft.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+		case reflect.Float32:
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_FLOAT.Enum()
+		case reflect.Float64:
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum()
+		case reflect.String:
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+		case reflect.Bool:
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum()
+		case reflect.Struct:
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			field.TypeName = proto.String(g.message(ft))
+		case reflect.Map:
+			if ft.Key().Kind() != reflect.String {
+				log.Fatalf("%s.%s: maps need string keys", t.Name(), f.Name)
+			}
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			field.TypeName = proto.String(".google.protobuf.Struct")
+		case reflect.Interface:
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			field.TypeName = proto.String(".google.protobuf.Value")
+		default:
+			log.Fatalf("%s.%s: unsupported type %s", t.Name(), f.Name, f.Type)
+		}
+
+		message.Field = append(message.Field, field)
+	}
+}
+
+// protoSource returns the proto definition of the generated file
+func (g *generator) protoSource() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen.go from the types of gamemodels. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "syntax = \"proto3\";\n\npackage %s;\n\nimport \"%s\";\n\n", protoPackage, structProto)
+	fmt.Fprintf(&b, "option go_package = \"%s\";\n", goPackage)
+
+	for _, message := range g.file.MessageType {
+		fmt.Fprintf(&b, "\n// %s is gamemodels.%[1]s\nmessage %[1]s {\n", message.GetName())
+		for _, field := range message.Field {
+			label := ""
+			if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+				label = "repeated "
+			}
+			typeName := strings.TrimPrefix(field.GetTypeName(), "."+protoPackage+".")
+			if typeName == "" {
+				typeName = strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+			}
+			fmt.Fprintf(&b, "  %s%s %s = %d [json_name = \"%s\"];\n",
+				label, strings.TrimPrefix(typeName, "."), field.GetName(), field.GetNumber(), field.GetJsonName())
+		}
+		fmt.Fprintf(&b, "}\n")
+	}
+
+	return b.Bytes()
+}
+
+// compile runs protoc-gen-go on the generated file
+func (g *generator) compile() []*pluginpb.CodeGeneratorResponse_File {
+	request, err := proto.Marshal(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{protoFile},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(structpb.File_google_protobuf_struct_proto),
+			g.file,
+		},
+	})
+	if err != nil {
+		log.Fatalf("could not encode the code generator request: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", "google.golang.org/protobuf/cmd/protoc-gen-go")
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		log.Fatalf("protoc-gen-go failed: %v", err)
+	}
+
+	var response pluginpb.CodeGeneratorResponse
+	if err := proto.Unmarshal(output, &response); err != nil {
+		log.Fatalf("could not decode the code generator response: %v", err)
+	}
+	if response.Error != nil {
+		log.Fatalf("protoc-gen-go failed: %s", response.GetError())
+	}
+
+	return response.File
+}
+
+// commandsSource returns the map of the well-known commands to the constructors of their messages
+func commandsSource(responses map[string]string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen.go from the types of gamemodels. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package gamemodelspb\n\nimport \"google.golang.org/protobuf/proto\"\n\n")
+	fmt.Fprintf(&b, "var responseMessages = map[string]func() proto.Message{\n")
+	for _, command := range commands {
+		fmt.Fprintf(&b, "%q: func() proto.Message { return new(%s) },\n", command, responses[command])
+	}
+	fmt.Fprintf(&b, "}\n")
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("could not format commands.go: %v", err)
+	}
+	return source
+}
+
+func writeFile(name string, data []byte) {
+	if err := ioutil.WriteFile(name, data, 0644); err != nil {
+		log.Fatalf("could not write %s: %v", name, err)
+	}
+}
+
+// snakeCase converts a Go field name like `TValueLocal` into a proto field name like `t_value_local`
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previousLower := !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if previousLower || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
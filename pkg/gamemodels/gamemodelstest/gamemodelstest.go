@@ -0,0 +1,106 @@
+// Package gamemodelstest replays the recorded game api exchanges of gamemodels/testdata in the tests of plugins.
+//
+// The exchanges are a single session of the wizard 12345678: the login, a dungeon and a trial tower run, shop
+// purchases, a summon, an arena battle, rune changes, a unit upgrade and the next login after the session.
+// The response of an exchange is `<name>.json`, its request `<name>.request.json`. Variants of a command are
+// named `<command>.<variant>`, e.g. `BuyShopItem.refill`.
+package gamemodelstest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+// WizardId is the wizard of the recorded session
+const WizardId = 12345678
+
+// session are the exchanges of the recorded session in the order they were sent, without the next login
+var session = []string{
+	"HubUserLogin",
+	"GetWizardInfo",
+	"BattleDungeonStart",
+	"BattleTrialTowerStart_v2",
+	"BattleDungeonResult_V2",
+	"BattleTrialTowerResult_v2",
+	"BuyShopItem",
+	"BuyShopItem.refill",
+	"SummonUnit",
+	"BattleArenaResult",
+	"UnequipRune",
+	"EquipRune",
+	"SellRune",
+	"UpgradeUnit",
+}
+
+// Handler handles game api events, e.g. an in-process plugin, see pmanager.Plugin
+type Handler interface {
+	Handle(ctx context.Context, ev events.ApiEventMsg) error
+}
+
+// Event returns the recorded exchange name as a game api event. Exchanges without a recorded request are sent
+// with the request `{"command": "<command>"}`.
+func Event(t testing.TB, name string) events.ApiEventMsg {
+	t.Helper()
+
+	command := strings.SplitN(name, ".", 2)[0]
+	ev := events.ApiEventMsg{Command: command, Request: fmt.Sprintf(`{"command": %q}`, command)}
+
+	response, err := ioutil.ReadFile(filepath.Join(testdata(), name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev.Response = string(response)
+
+	request, err := ioutil.ReadFile(filepath.Join(testdata(), name+".request.json"))
+	switch {
+	case err == nil:
+		ev.Request = string(request)
+	case !os.IsNotExist(err):
+		t.Fatal(err)
+	}
+
+	return ev
+}
+
+// Session returns the events of the recorded session from the login to the unit upgrade. The state of the account
+// after the session is the next login `HubUserLogin.after`.
+func Session(t testing.TB) []events.ApiEventMsg {
+	t.Helper()
+
+	evs := make([]events.ApiEventMsg, 0, len(session))
+	for _, name := range session {
+		evs = append(evs, Event(t, name))
+	}
+	return evs
+}
+
+// Failed returns the event with the response of a failed command, the game api sends no game objects then
+func Failed(ev events.ApiEventMsg) events.ApiEventMsg {
+	ev.Response = fmt.Sprintf(`{"command": %q, "ret_code": 1}`, ev.Command)
+	return ev
+}
+
+// Handle passes the events to h in order and fails the test on the first error
+func Handle(t testing.TB, h Handler, evs ...events.ApiEventMsg) {
+	t.Helper()
+
+	for _, ev := range evs {
+		if err := h.Handle(context.Background(), ev); err != nil {
+			t.Fatalf("Handle(%s) = %v", ev.Command, err)
+		}
+	}
+}
+
+// testdata returns the directory of the recorded exchanges, independent of the package of the test
+func testdata() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "testdata")
+}
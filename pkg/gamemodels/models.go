@@ -1,8 +1,10 @@
 package gamemodels
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 )
 
 //
@@ -251,6 +253,30 @@ func (w WizardInfo) Equal(other WizardInfo) bool {
 	return w.WizardId == other.WizardId
 }
 
+//
+// enum: BattleOutcome
+// BattleOutcome is the `win_lose` of battle results, see BattleDungeonResultV2 and BattleTrialTowerResultV2
+type BattleOutcome int
+
+const (
+	BattleWin  BattleOutcome = 1
+	BattleLose BattleOutcome = 2
+)
+
+func (bo BattleOutcome) String() string {
+	names := map[BattleOutcome]string{
+		BattleWin:  "Win",
+		BattleLose: "Lose",
+	}
+
+	name, ok := names[bo]
+	if !ok {
+		return "Unknown"
+	}
+
+	return name
+}
+
 //
 // type: DungeonReward
 type DungeonReward struct {
@@ -284,50 +310,78 @@ func (r RuneStat) IsGrinded() bool {
 	return r.GrindValue != 0
 }
 
-// todo(lyrex): figure out how to write custom tojson/fromjson functions
+// UnmarshalJSON decodes the array encoding of the game api (`[effect_type, effect_value]` for main and innate
+// stats, `[effect_type, effect_value, is_enchanted, grind_value]` for substats) and the object encoding of RuneStat
+func (r *RuneStat) UnmarshalJSON(data []byte) error {
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		type plainRuneStat RuneStat
+		return json.Unmarshal(data, (*plainRuneStat)(r))
+	}
+
+	stat, err := newRuneStat(values)
+	if err != nil {
+		return err
+	}
+	if stat == nil {
+		*r = RuneStat{}
+	} else {
+		*r = *stat
+	}
+	return nil
+}
 
+// NewRuneStatFromObject creates a rune stat from its array encoding as decoded by encoding/json. It returns nil
+// without an error for empty stats, e.g. the innate stat of runes without one.
 func NewRuneStatFromObject(obj interface{}) (*RuneStat, error) {
 	if obj == nil {
 		return nil, errors.New("obj is nil")
 	}
-	data, ok := obj.([]int)
 
-	if !ok {
+	var values []int
+	switch data := obj.(type) {
+	case []int:
+		values = data
+	case []interface{}:
+		for _, value := range data {
+			switch v := value.(type) {
+			case float64:
+				values = append(values, int(v))
+			case json.Number:
+				i, err := v.Int64()
+				if err != nil {
+					return nil, fmt.Errorf("obj has an invalid value: %w", err)
+				}
+				values = append(values, int(i))
+			default:
+				return nil, errors.New("obj is not a rune stat")
+			}
+		}
+	default:
 		return nil, errors.New("obj is not a rune stat")
 	}
 
+	return newRuneStat(values)
+}
+
+func newRuneStat(data []int) (*RuneStat, error) {
 	dataLen := len(data)
-	if dataLen < 2 || (dataLen != 2 && dataLen != 4) {
+	if dataLen != 2 && dataLen != 4 {
 		return nil, fmt.Errorf("obj has an invalid length: %d", dataLen)
 	}
 
-	// return nil if all values are 0. this means it's an empty stat
-	// todo(lyrex): check if this is needed
-	if data[0] == 0 || data[1] == 0 {
+	// an effect type of 0 is an empty stat
+	if data[0] == 0 {
 		return nil, nil
 	}
 
-	effectType := EffectType(data[0])
-	effectValue := data[1]
-
-	var runeStat *RuneStat
-	switch dataLen {
-	case 2:
-		runeStat = &RuneStat{
-			EffectType:  effectType,
-			EffectValue: effectValue,
-			IsEnchanted: false,
-			GrindValue:  0,
-		}
-	case 4:
-		runeStat = &RuneStat{
-			EffectType:  effectType,
-			EffectValue: effectValue,
-			IsEnchanted: data[2] == 1,
-			GrindValue:  data[3],
-		}
-	default:
-		return nil, errors.New("obj data is corrupt")
+	runeStat := &RuneStat{
+		EffectType:  EffectType(data[0]),
+		EffectValue: data[1],
+	}
+	if dataLen == 4 {
+		runeStat.IsEnchanted = data[2] == 1
+		runeStat.GrindValue = data[3]
 	}
 
 	return runeStat, nil
@@ -335,10 +389,11 @@ func NewRuneStatFromObject(obj interface{}) (*RuneStat, error) {
 
 //
 // type: Rune
-
 type Rune struct {
 	RuneId          int         `json:"rune_id"`
 	WizardId        int         `json:"wizard_id"`
+	OccupiedType    int         `json:"occupied_type"`
+	OccupiedId      int         `json:"occupied_id"`
 	RuneSet         RuneSet     `json:"set_id"`
 	Stars           int         `json:"class"`
 	Level           int         `json:"upgrade_curr"`
@@ -352,87 +407,274 @@ type Rune struct {
 	Substats        []RuneStat  `json:"sec_eff"`
 }
 
-/*
-
-@dataclass
-class Rune:
-    rune_id: int
-    wizard_id: int
-    rune_set: RuneSet
-
-    stars: int
-    level: int
-    slot: int
-    quality: RuneQuality
-    original_quality: RuneQuality
-    ancient: bool
-    sell_value: int
-    main_stat: RuneStat
-    innate_stat: Optional[RuneStat]
-    substats: List[RuneStat]
-
-    @classmethod
-    def from_json_object(cls, rune_data: Dict):
-        ok, key = cls._validate_fields(rune_data)
-        if not ok:
-            raise ValueError(f"Rune is missing required field {key}")
-
-        rune_id = rune_data["rune_id"]
-        wizard_id = rune_data["wizard_id"]
-        rune_set = RuneSet(rune_data["set_id"])
-        stars = rune_data["class"]
-        if stars > 10:
-            stars -= 10
-            ancient = True
-        else:
-            ancient = False
-        level = rune_data["upgrade_curr"]
-        slot = rune_data["slot_no"]
-        quality = RuneQuality(rune_data["rank"])
-        original_quality = RuneQuality(rune_data["extra"])
-        sell_value = rune_data["sell_value"]
-
-        main_stat = RuneStat.from_object(rune_data["pri_eff"])
-        innate_stat = RuneStat.from_object(rune_data["prefix_eff"])
-        substats = [RuneStat.from_object(x) for x in rune_data["sec_eff"]]
-
-        r = cls(rune_id, wizard_id, rune_set, stars, level, slot, quality, original_quality, ancient, sell_value,
-                main_stat, innate_stat, substats)
-
-        return r
-
-    @staticmethod
-    def _validate_fields(rune_data: Dict) -> Tuple[bool, Optional[str]]:
-        _REQUIRED_KEYS = ["rune_id", "wizard_id", "set_id", "class", "upgrade_curr", "slot_no", "rank",
-                          "extra", "sell_value", "pri_eff", "prefix_eff", "sec_eff"]
-        for key in _REQUIRED_KEYS:
-            if key not in rune_data:
-                return False, key
-        return True, None
-
-
-@dataclass_json
-@dataclass
-class Unit:
-    unit_id: int
-    wizard_id: int
-    unit_master_id: int
-    unit_level: int
-    unit_class: int = field(metadata=config(field_name="class"))
-    unit_con: int = field(metadata=config(field_name="con"))
-    unit_atk: int = field(metadata=config(field_name="atk"))
-    unit_def: int = field(metadata=config(field_name="def"))
-    unit_spd: int = field(metadata=config(field_name="spd"))
-    unit_resist: int = field(metadata=config(field_name="resist"))
-    unit_accuracy: int = field(metadata=config(field_name="accuracy"))
-    unit_critical_rate: int = field(metadata=config(field_name="critical_rate"))
-    unit_critical_damage: int = field(metadata=config(field_name="critical_damage"))
-    # unit_skills: List[UnitSkill] = field(metadata=config(field_name="skills"))
-    unit_runes: List[Rune] = field(metadata=config(field_name="runes", decoder=Rune.from_json_object))
-    unit_attribute: UnitAttribute = field(metadata=config(field_name="attribute"))
-
-    def __eq__(self, other: object) -> bool:
-        if not isinstance(other, Unit):
-            return False
-        return self.unit_id == other.unit_id
-*/
+// UnmarshalJSON decodes a rune of the game api. The game encodes ancient runes with 10 additional stars.
+func (r *Rune) UnmarshalJSON(data []byte) error {
+	type plainRune Rune
+	if err := json.Unmarshal(data, (*plainRune)(r)); err != nil {
+		return err
+	}
+
+	if r.Stars > 10 {
+		r.Stars -= 10
+		r.Ancient = true
+	}
+	if r.InnateStat != nil && r.InnateStat.EffectType == 0 {
+		r.InnateStat = nil
+	}
+
+	return nil
+}
+
+func (r Rune) Equal(other Rune) bool {
+	return r.RuneId == other.RuneId
+}
+
+//
+// type: UnitSkill
+type UnitSkill struct {
+	SkillId int `json:"skill_id"`
+	Level   int `json:"level"`
+}
+
+// UnmarshalJSON decodes the array encoding of the game api (`[skill_id, level]`) and the object encoding of UnitSkill
+func (s *UnitSkill) UnmarshalJSON(data []byte) error {
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		type plainUnitSkill UnitSkill
+		return json.Unmarshal(data, (*plainUnitSkill)(s))
+	}
+
+	if len(values) != 2 {
+		return fmt.Errorf("skill has an invalid length: %d", len(values))
+	}
+	*s = UnitSkill{SkillId: values[0], Level: values[1]}
+	return nil
+}
+
+//
+// enum: ArtifactType
+type ArtifactType int
+
+const (
+	ArtifactElement   ArtifactType = 1
+	ArtifactArchetype ArtifactType = 2
+)
+
+func (at ArtifactType) String() string {
+	names := map[ArtifactType]string{
+		ArtifactElement:   "Element",
+		ArtifactArchetype: "Archetype",
+	}
+
+	name, ok := names[at]
+	if !ok {
+		return "Unknown"
+	}
+
+	return name
+}
+
+//
+// enum: UnitArchetype
+type UnitArchetype int
+
+const (
+	ArchetypeAttack   UnitArchetype = 1
+	ArchetypeDefense  UnitArchetype = 2
+	ArchetypeHp       UnitArchetype = 3
+	ArchetypeSupport  UnitArchetype = 4
+	ArchetypeMaterial UnitArchetype = 5
+)
+
+func (ua UnitArchetype) String() string {
+	names := map[UnitArchetype]string{
+		ArchetypeAttack:   "Attack",
+		ArchetypeDefense:  "Defense",
+		ArchetypeHp:       "HP",
+		ArchetypeSupport:  "Support",
+		ArchetypeMaterial: "Material",
+	}
+
+	name, ok := names[ua]
+	if !ok {
+		return "Unknown"
+	}
+
+	return name
+}
+
+//
+// type: ArtifactEffect
+type ArtifactEffect struct {
+	EffectId int     `json:"effect_id"`
+	Value    float64 `json:"value"`
+	// Upgrades is the number of times the effect was increased by upgrading the artifact
+	Upgrades int `json:"upgrades"`
+}
+
+// UnmarshalJSON decodes the array encoding of the game api (`[effect_id, value, upgrades, ...]`) and the
+// object encoding of ArtifactEffect
+func (e *ArtifactEffect) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		type plainArtifactEffect ArtifactEffect
+		return json.Unmarshal(data, (*plainArtifactEffect)(e))
+	}
+
+	if len(values) < 2 {
+		return fmt.Errorf("artifact effect has an invalid length: %d", len(values))
+	}
+	*e = ArtifactEffect{EffectId: int(values[0]), Value: values[1]}
+	if len(values) > 2 {
+		e.Upgrades = int(values[2])
+	}
+	return nil
+}
+
+//
+// type: Artifact
+type Artifact struct {
+	ArtifactId      int              `json:"rid"`
+	WizardId        int              `json:"wizard_id"`
+	OccupiedId      int              `json:"occupied_id"`
+	Slot            int              `json:"slot"`
+	Type            ArtifactType     `json:"type"`
+	Attribute       UnitAttribute    `json:"attribute"`  // only set for ArtifactElement
+	Archetype       UnitArchetype    `json:"unit_style"` // only set for ArtifactArchetype
+	Level           int              `json:"level"`
+	Quality         RuneQuality      `json:"rank"`
+	OriginalQuality RuneQuality      `json:"natural_rank"`
+	MainStat        ArtifactEffect   `json:"pri_effect"`
+	Substats        []ArtifactEffect `json:"sec_effects"`
+}
+
+func (a Artifact) Equal(other Artifact) bool {
+	return a.ArtifactId == other.ArtifactId
+}
+
+//
+// type: HomunculusSkill
+type HomunculusSkill struct {
+	UnitId     int `json:"unit_id"`
+	SkillId    int `json:"skill_id"`
+	SkillDepth int `json:"skill_depth"`
+	Level      int `json:"level"`
+}
+
+// HomunculusBuild is the skill tree a homunculus unit has learned, see HomunculusBuilds
+type HomunculusBuild struct {
+	UnitId int
+	Skills []HomunculusSkill
+}
+
+// HomunculusBuilds groups the homunculus_skill_list of the game api by unit
+func HomunculusBuilds(skills []HomunculusSkill) map[int]HomunculusBuild {
+	builds := map[int]HomunculusBuild{}
+	for _, skill := range skills {
+		build := builds[skill.UnitId]
+		build.UnitId = skill.UnitId
+		build.Skills = append(build.Skills, skill)
+		builds[skill.UnitId] = build
+	}
+
+	for _, build := range builds {
+		sort.Slice(build.Skills, func(i, j int) bool { return build.Skills[i].SkillDepth < build.Skills[j].SkillDepth })
+	}
+	return builds
+}
+
+//
+// type: Unit
+type Unit struct {
+	UnitId         int           `json:"unit_id"`
+	WizardId       int           `json:"wizard_id"`
+	UnitMasterId   int           `json:"unit_master_id"`
+	Level          int           `json:"unit_level"`
+	Stars          int           `json:"class"`
+	Con            int           `json:"con"` // hp / 15
+	Atk            int           `json:"atk"`
+	Def            int           `json:"def"`
+	Spd            int           `json:"spd"`
+	Resist         int           `json:"resist"`
+	Accuracy       int           `json:"accuracy"`
+	CriticalRate   int           `json:"critical_rate"`
+	CriticalDamage int           `json:"critical_damage"`
+	Attribute      UnitAttribute `json:"attribute"`
+	Homunculus     int           `json:"homunculus"`
+	HomunculusName string        `json:"homunculus_name"`
+	Skills         []UnitSkill   `json:"skills"`
+	Runes          []Rune        `json:"runes"`
+	Artifacts      []Artifact    `json:"artifacts"`
+}
+
+// UnmarshalJSON decodes a unit of the game api. The game sends the equipped runes and artifacts either as
+// array or as object keyed by slot, Runes and Artifacts are always ordered by slot.
+func (u *Unit) UnmarshalJSON(data []byte) error {
+	type plainUnit Unit
+	var decoded struct {
+		*plainUnit
+		Runes     json.RawMessage `json:"runes"`
+		Artifacts json.RawMessage `json:"artifacts"`
+	}
+	decoded.plainUnit = (*plainUnit)(u)
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	u.Runes = nil
+	if err := decodeSlotted(decoded.Runes, &u.Runes); err != nil {
+		return fmt.Errorf("unit %d has invalid runes: %w", u.UnitId, err)
+	}
+	sort.Slice(u.Runes, func(i, j int) bool { return u.Runes[i].Slot < u.Runes[j].Slot })
+
+	u.Artifacts = nil
+	if err := decodeSlotted(decoded.Artifacts, &u.Artifacts); err != nil {
+		return fmt.Errorf("unit %d has invalid artifacts: %w", u.UnitId, err)
+	}
+	sort.Slice(u.Artifacts, func(i, j int) bool { return u.Artifacts[i].Slot < u.Artifacts[j].Slot })
+
+	return nil
+}
+
+// decodeSlotted decodes a json array or a json object keyed by slot into the slice pointed to by v
+func decodeSlotted(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err == nil {
+		return nil
+	}
+
+	var bySlot map[string]json.RawMessage
+	if err := json.Unmarshal(data, &bySlot); err != nil {
+		return err
+	}
+	elements := make([]json.RawMessage, 0, len(bySlot))
+	for _, element := range bySlot {
+		elements = append(elements, element)
+	}
+
+	array, err := json.Marshal(elements)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(array, v)
+}
+
+// Hp returns the base hp of the unit
+func (u Unit) Hp() int {
+	return u.Con * 15
+}
+
+func (u Unit) IsHomunculus() bool {
+	return u.Homunculus != 0
+}
+
+// Monster returns the master data of the unit, see LookupMonster
+func (u Unit) Monster() (Monster, bool) {
+	return LookupMonster(u.UnitMasterId)
+}
+
+func (u Unit) Equal(other Unit) bool {
+	return u.UnitId == other.UnitId
+}
@@ -0,0 +1,141 @@
+package gamemodels
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRuneStatUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    RuneStat
+		wantErr bool
+	}{
+		{`[4, 63]`, RuneStat{EffectType: 4, EffectValue: 63}, false},
+		{`[8, 5, 1, 0]`, RuneStat{EffectType: 8, EffectValue: 5, IsEnchanted: true}, false},
+		{`[2, 6, 0, 5]`, RuneStat{EffectType: 2, EffectValue: 6, GrindValue: 5}, false},
+		{`[0, 0]`, RuneStat{}, false},
+		{`{"effect_type": 10, "effect_value": 7, "is_enchanted": true}`, RuneStat{EffectType: 10, EffectValue: 7, IsEnchanted: true}, false},
+		{`[4, 63, 0]`, RuneStat{}, true},
+		{`"4"`, RuneStat{}, true},
+	}
+	for _, tt := range tests {
+		var got RuneStat
+		err := json.Unmarshal([]byte(tt.data), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, want error %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestNewRuneStatFromObject(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     interface{}
+		want    *RuneStat
+		wantErr bool
+	}{
+		{"ints", []int{4, 63}, &RuneStat{EffectType: 4, EffectValue: 63}, false},
+		{"decoded json", []interface{}{float64(8), float64(5), float64(0), float64(2)}, &RuneStat{EffectType: 8, EffectValue: 5, GrindValue: 2}, false},
+		{"json numbers", []interface{}{json.Number("9"), json.Number("6")}, &RuneStat{EffectType: 9, EffectValue: 6}, false},
+		{"empty stat", []interface{}{float64(0), float64(0)}, nil, false},
+		{"nil", nil, nil, true},
+		{"invalid length", []int{4}, nil, true},
+		{"invalid value", []interface{}{"4", "63"}, nil, true},
+		{"invalid number", []interface{}{json.Number("4.5"), json.Number("63")}, nil, true},
+		{"not an array", map[string]interface{}{}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRuneStatFromObject(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("rune stat = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitSkillUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    UnitSkill
+		wantErr bool
+	}{
+		{`[1341, 5]`, UnitSkill{SkillId: 1341, Level: 5}, false},
+		{`{"skill_id": 1342, "level": 2}`, UnitSkill{SkillId: 1342, Level: 2}, false},
+		{`[1341]`, UnitSkill{}, true},
+	}
+	for _, tt := range tests {
+		var got UnitSkill
+		err := json.Unmarshal([]byte(tt.data), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, want error %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestArtifactEffectUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    ArtifactEffect
+		wantErr bool
+	}{
+		{`[100, 1500, 5, 0, 0]`, ArtifactEffect{EffectId: 100, Value: 1500, Upgrades: 5}, false},
+		{`[215, 6.5]`, ArtifactEffect{EffectId: 215, Value: 6.5}, false},
+		{`{"effect_id": 206, "value": 10, "upgrades": 2}`, ArtifactEffect{EffectId: 206, Value: 10, Upgrades: 2}, false},
+		{`[100]`, ArtifactEffect{}, true},
+	}
+	for _, tt := range tests {
+		var got ArtifactEffect
+		err := json.Unmarshal([]byte(tt.data), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, want error %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestUnitUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		slots   []int
+		wantErr bool
+	}{
+		{"rune array", `{"unit_id": 1, "runes": [{"slot_no": 3}, {"slot_no": 1}]}`, []int{1, 3}, false},
+		{"slot-keyed runes", `{"unit_id": 1, "runes": {"5": {"slot_no": 5}, "2": {"slot_no": 2}}}`, []int{2, 5}, false},
+		{"no runes", `{"unit_id": 1, "runes": []}`, nil, false},
+		{"null runes", `{"unit_id": 1, "runes": null}`, nil, false},
+		{"invalid runes", `{"unit_id": 1, "runes": 5}`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var unit Unit
+			err := json.Unmarshal([]byte(tt.data), &unit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			var slots []int
+			for _, r := range unit.Runes {
+				slots = append(slots, r.Slot)
+			}
+			if !equalInts(slots, tt.slots) {
+				t.Errorf("runes in slots %v, want %v", slots, tt.slots)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Code generated by monstergen.go from the SWARFARM monster list. DO NOT EDIT.
+
+package gamemodels
+
+// bundledMonsterDataJSON is the master data of 24 monster families in the json encoding of MonsterData
+const bundledMonsterDataJSON = `{
+	"version": "2026-10-19",
+	"families": {
+		"101": {
+			"name": "Fairy",
+			"awakened": {
+				"1": "Elucia",
+				"2": "Iselia",
+				"3": "Aeilene",
+				"4": "Neal",
+				"5": "Sorin"
+			}
+		},
+		"102": {
+			"name": "Imp",
+			"awakened": {
+				"1": "Fynn",
+				"2": "Cogma",
+				"3": "Ralph",
+				"4": "Taru",
+				"5": "Garok"
+			}
+		},
+		"103": {
+			"name": "Pixie",
+			"awakened": {
+				"1": "Kacey",
+				"2": "Tatu",
+				"3": "Shannon",
+				"4": "Cheryl",
+				"5": "Camaryn"
+			}
+		},
+		"104": {
+			"name": "Yeti",
+			"awakened": {
+				"1": "Kunda",
+				"2": "Tantra",
+				"3": "Rakaja",
+				"4": "Arkajan",
+				"5": "Kumae"
+			}
+		},
+		"105": {
+			"name": "Harpy",
+			"awakened": {
+				"1": "Ramira",
+				"2": "Lucasha",
+				"3": "Prilea",
+				"4": "Kabilla",
+				"5": "Hellea"
+			}
+		},
+		"106": {
+			"name": "Hellhound",
+			"awakened": {
+				"1": "Tarq",
+				"2": "Sieq",
+				"3": "Gamir",
+				"4": "Rex",
+				"5": "Karvan"
+			}
+		},
+		"107": {
+			"name": "Warbear",
+			"awakened": {
+				"1": "Dagora",
+				"2": "Ursha",
+				"3": "Ramagos",
+				"4": "Lusha",
+				"5": "Gorgo"
+			}
+		},
+		"108": {
+			"name": "Elemental",
+			"awakened": {
+				"1": "Daharenos",
+				"2": "Bremis",
+				"3": "Taharus",
+				"4": "Priz",
+				"5": "Camules"
+			}
+		},
+		"109": {
+			"name": "Garuda",
+			"awakened": {
+				"1": "Konamiya",
+				"2": "Cahule",
+				"3": "Lindermen",
+				"4": "Teon",
+				"5": "Rocher"
+			}
+		},
+		"110": {
+			"name": "Inugami",
+			"awakened": {
+				"1": "Icaru",
+				"2": "Raoq",
+				"3": "Ramahan",
+				"4": "Belladeon",
+				"5": "Kro"
+			}
+		},
+		"111": {
+			"name": "Salamander",
+			"awakened": {
+				"1": "Kaimann",
+				"2": "Krakdon",
+				"3": "Lukan",
+				"4": "Sharman",
+				"5": "Decamaron"
+			}
+		},
+		"112": {
+			"name": "Nine-tailed Fox",
+			"awakened": {
+				"1": "Soha",
+				"2": "Shihwa",
+				"3": "Arang",
+				"4": "Chamie",
+				"5": "Kamiya"
+			}
+		},
+		"113": {
+			"name": "Serpent",
+			"awakened": {
+				"1": "Shailoq",
+				"2": "Fao",
+				"3": "Ermeda",
+				"4": "Elpuria",
+				"5": "Mantura"
+			}
+		},
+		"114": {
+			"name": "Golem",
+			"awakened": {
+				"1": "Kuhn",
+				"2": "Kugo",
+				"3": "Ragion",
+				"4": "Groggo",
+				"5": "Maggi"
+			}
+		},
+		"115": {
+			"name": "Griffon",
+			"awakened": {
+				"1": "Kahn",
+				"2": "Spectra",
+				"3": "Bernard",
+				"4": "Shamar",
+				"5": "Varus"
+			}
+		},
+		"116": {
+			"name": "Undine",
+			"awakened": {
+				"1": "Mikene",
+				"2": "Atenai",
+				"3": "Delphoi",
+				"4": "Icasha",
+				"5": "Tilasha"
+			}
+		},
+		"117": {
+			"name": "Inferno",
+			"awakened": {
+				"1": "Purian",
+				"2": "Tagaros",
+				"3": "Anduril",
+				"4": "Eludain",
+				"5": "Drogan"
+			}
+		},
+		"118": {
+			"name": "Sylph",
+			"awakened": {
+				"1": "Tyron",
+				"2": "Baretta",
+				"3": "Shimitae",
+				"4": "Eredas",
+				"5": "Aschubel"
+			}
+		},
+		"119": {
+			"name": "Sylphid",
+			"awakened": {
+				"1": "Lumirecia",
+				"2": "Fria",
+				"3": "Acasis",
+				"4": "Mihael",
+				"5": "Icares"
+			}
+		},
+		"121": {
+			"name": "Harpu",
+			"awakened": {
+				"1": "Sisroo",
+				"2": "Colleen",
+				"3": "Seal",
+				"4": "Sia",
+				"5": "Seren"
+			}
+		},
+		"122": {
+			"name": "Mystic Witch",
+			"awakened": {
+				"1": "Megan",
+				"2": "Rebecca",
+				"3": "Silia",
+				"4": "Linda",
+				"5": "Gina"
+			}
+		},
+		"123": {
+			"name": "Grim Reaper",
+			"awakened": {
+				"1": "Hemos",
+				"2": "Sath",
+				"3": "Hiva",
+				"4": "Prom",
+				"5": "Thrain"
+			}
+		},
+		"124": {
+			"name": "Phantom Thief",
+			"awakened": {
+				"1": "Luer",
+				"2": "Jean",
+				"3": "Julien",
+				"4": "Louis",
+				"5": "Guillaume"
+			}
+		},
+		"141": {
+			"name": "Joker",
+			"awakened": {
+				"1": "Sian",
+				"2": "Jojo",
+				"3": "Lushen",
+				"4": "Figaro",
+				"5": "Liebli"
+			}
+		}
+	}
+}`
@@ -0,0 +1,137 @@
+//go:build ignore
+// +build ignore
+
+// monstergen generates monsterdata.go, the bundled monster master data, from the monster list of the SWARFARM
+// api (https://swarfarm.com/api/v2/monsters/). Use -input to read a saved export of the list instead.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const monstersUrl = "https://swarfarm.com/api/v2/monsters/?page_size=1000"
+
+// monster is an entry of the SWARFARM monster list
+type monster struct {
+	Com2usId    int    `json:"com2us_id"`
+	Name        string `json:"name"`
+	AwakenLevel int    `json:"awaken_level"`
+}
+
+// page is a page of the SWARFARM monster list, an export is a single page without a next page
+type page struct {
+	Next    *string   `json:"next"`
+	Results []monster `json:"results"`
+}
+
+type family struct {
+	Name     string            `json:"name"`
+	Awakened map[string]string `json:"awakened,omitempty"`
+}
+
+func main() {
+	input := flag.String("input", "", "saved export of the SWARFARM monster list")
+	version := flag.String("version", time.Now().UTC().Format("2006-01-02"), "version of the generated master data")
+	flag.Parse()
+
+	var monsters []monster
+	if *input != "" {
+		f, err := os.Open(*input)
+		if err != nil {
+			log.Fatal(err)
+		}
+		monsters = readPage(f).Results
+		f.Close()
+	} else {
+		for url := monstersUrl; url != ""; {
+			resp, err := http.Get(url)
+			if err != nil {
+				log.Fatal(err)
+			}
+			p := readPage(resp.Body)
+			resp.Body.Close()
+
+			monsters = append(monsters, p.Results...)
+			url = ""
+			if p.Next != nil {
+				url = *p.Next
+			}
+		}
+	}
+
+	families := map[int]*family{}
+	for _, m := range monsters {
+		// unit master ids consist of the family, the awakening and the element, see gamemodels.MonsterData
+		familyId, awakening, attribute := m.Com2usId/100, m.Com2usId/10%10, m.Com2usId%10
+		if familyId == 0 || attribute < 1 || attribute > 5 {
+			continue
+		}
+
+		f, ok := families[familyId]
+		if !ok {
+			f = &family{Awakened: map[string]string{}}
+			families[familyId] = f
+		}
+		switch {
+		case awakening == 0 && m.AwakenLevel == 0:
+			f.Name = m.Name
+		case awakening == 1:
+			f.Awakened[strconv.Itoa(attribute)] = m.Name
+		}
+	}
+
+	for id, f := range families {
+		if f.Name == "" {
+			log.Printf("skipping family %d without unawakened monsters", id)
+			delete(families, id)
+		}
+	}
+	if len(families) == 0 {
+		log.Fatal("no monster families found")
+	}
+
+	data, err := json.MarshalIndent(struct {
+		Version  string          `json:"version"`
+		Families map[int]*family `json:"families"`
+	}{*version, families}, "", "\t")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	writeSource(len(families), data)
+}
+
+func readPage(r io.Reader) page {
+	var p page
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		log.Fatalf("invalid monster list: %v", err)
+	}
+	return p
+}
+
+func writeSource(families int, data []byte) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by monstergen.go from the SWARFARM monster list. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package gamemodels\n\n")
+	fmt.Fprintf(&b, "// bundledMonsterDataJSON is the master data of %d monster families in the json encoding of MonsterData\n", families)
+	fmt.Fprintf(&b, "const bundledMonsterDataJSON = `%s`\n", data)
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("could not format monsterdata.go: %v", err)
+	}
+	if err := ioutil.WriteFile("monsterdata.go", source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,144 @@
+package gamemodels
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// type: Monster
+type Monster struct {
+	UnitMasterId int           `json:"unit_master_id"`
+	FamilyId     int           `json:"family_id"`
+	Family       string        `json:"family"`
+	Name         string        `json:"name"`
+	Attribute    UnitAttribute `json:"attribute"`
+	Awakened     bool          `json:"awakened"`
+}
+
+// String returns the name and element of the monster, e.g. `Elucia (Water)`
+func (m Monster) String() string {
+	return fmt.Sprintf("%s (%s)", m.Name, m.Attribute)
+}
+
+// MonsterFamily is the master data of all monsters that share their unawakened form
+type MonsterFamily struct {
+	Name string `json:"name"`
+	// AwakenedNames by the element of the monster
+	AwakenedNames map[UnitAttribute]string `json:"awakened"`
+}
+
+// MonsterData maps the unit_master_id of the game api to the name and element of the monster. A unit_master_id
+// consists of the family id, the awakening and the element, e.g. 10111 is the awakened (1) water (1) fairy (101).
+// Second awakened monsters have the awakening 2 and keep their awakened name.
+type MonsterData struct {
+	Version  string                `json:"version"`
+	Families map[int]MonsterFamily `json:"families"`
+}
+
+// Lookup returns the monster of unitMasterId, ok is false if its family is unknown
+func (d MonsterData) Lookup(unitMasterId int) (monster Monster, ok bool) {
+	familyId := unitMasterId / 100
+	family, ok := d.Families[familyId]
+	if !ok {
+		return Monster{}, false
+	}
+
+	monster = Monster{
+		UnitMasterId: unitMasterId,
+		FamilyId:     familyId,
+		Family:       family.Name,
+		Attribute:    UnitAttribute(unitMasterId % 10),
+		Awakened:     unitMasterId/10%10 >= 1,
+	}
+
+	if name, ok := family.AwakenedNames[monster.Attribute]; ok && monster.Awakened {
+		monster.Name = name
+	} else {
+		monster.Name = monster.Attribute.String() + " " + family.Name
+	}
+
+	return monster, true
+}
+
+// LoadMonsterData reads master data in the json encoding of MonsterData, e.g. to replace BundledMonsterData with
+// a newer table
+func LoadMonsterData(r io.Reader) (MonsterData, error) {
+	var data struct {
+		Version  string `json:"version"`
+		Families map[string]struct {
+			Name          string            `json:"name"`
+			AwakenedNames map[string]string `json:"awakened"`
+		} `json:"families"`
+	}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return MonsterData{}, err
+	}
+
+	monsterData := MonsterData{Version: data.Version, Families: make(map[int]MonsterFamily, len(data.Families))}
+	for id, f := range data.Families {
+		familyId, err := strconv.Atoi(id)
+		if err != nil {
+			return MonsterData{}, fmt.Errorf("invalid family id %q: %w", id, err)
+		}
+
+		family := MonsterFamily{Name: f.Name, AwakenedNames: map[UnitAttribute]string{}}
+		for attribute, name := range f.AwakenedNames {
+			a, err := strconv.Atoi(attribute)
+			if err != nil {
+				return MonsterData{}, fmt.Errorf("invalid attribute %q of family %d: %w", attribute, familyId, err)
+			}
+			family.AwakenedNames[UnitAttribute(a)] = name
+		}
+		monsterData.Families[familyId] = family
+	}
+
+	return monsterData, nil
+}
+
+var (
+	monsterDataMu sync.RWMutex
+	monsterData   = BundledMonsterData
+)
+
+// LookupMonster returns the monster of unitMasterId from the current master data, see SetMonsterData. Monsters
+// released after the master data was generated are unknown, callers have to handle a miss.
+func LookupMonster(unitMasterId int) (Monster, bool) {
+	monsterDataMu.RLock()
+	defer monsterDataMu.RUnlock()
+
+	return monsterData.Lookup(unitMasterId)
+}
+
+// MonsterDataVersion returns the version of the current master data
+func MonsterDataVersion() string {
+	monsterDataMu.RLock()
+	defer monsterDataMu.RUnlock()
+
+	return monsterData.Version
+}
+
+// SetMonsterData replaces the master data used by LookupMonster
+func SetMonsterData(data MonsterData) {
+	monsterDataMu.Lock()
+	defer monsterDataMu.Unlock()
+
+	monsterData = data
+}
+
+//go:generate go run monstergen.go
+
+// BundledMonsterData is the master data shipped with the proxy, it is generated from the SWARFARM monster list by
+// monstergen.go. Its Version is the date it was generated.
+var BundledMonsterData = mustLoadMonsterData(bundledMonsterDataJSON)
+
+func mustLoadMonsterData(data string) MonsterData {
+	monsterData, err := LoadMonsterData(strings.NewReader(data))
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled monster data: %v", err))
+	}
+	return monsterData
+}
@@ -0,0 +1,63 @@
+package gamemodels
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMonsterDataLookup(t *testing.T) {
+	tests := []struct {
+		unitMasterId int
+		want         Monster
+		ok           bool
+	}{
+		{10111, Monster{UnitMasterId: 10111, FamilyId: 101, Family: "Fairy", Name: "Elucia", Attribute: AttributeWater, Awakened: true}, true},
+		{10102, Monster{UnitMasterId: 10102, FamilyId: 101, Family: "Fairy", Name: "Fire Fairy", Attribute: AttributeFire}, true},
+		{14113, Monster{UnitMasterId: 14113, FamilyId: 141, Family: "Joker", Name: "Lushen", Attribute: AttributeWind, Awakened: true}, true},
+		{14123, Monster{UnitMasterId: 14123, FamilyId: 141, Family: "Joker", Name: "Lushen", Attribute: AttributeWind, Awakened: true}, true},
+		{99911, Monster{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := BundledMonsterData.Lookup(tt.unitMasterId)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("Lookup(%d) = %+v, %v, want %+v, %v", tt.unitMasterId, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBundledMonsterData(t *testing.T) {
+	if BundledMonsterData.Version == "" || len(BundledMonsterData.Families) == 0 {
+		t.Fatalf("bundled master data has version %q and %d families", BundledMonsterData.Version, len(BundledMonsterData.Families))
+	}
+	if version := MonsterDataVersion(); version != BundledMonsterData.Version {
+		t.Errorf("version = %s, want the version of the bundled master data %s", version, BundledMonsterData.Version)
+	}
+}
+
+func TestLoadMonsterData(t *testing.T) {
+	data, err := LoadMonsterData(strings.NewReader(`{"version": "2020-10-08", "families": {
+		"134": {"name": "Sky Dancer", "awakened": {"3": "Mihyang"}}
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetMonsterData(data)
+	defer SetMonsterData(BundledMonsterData)
+
+	if version := MonsterDataVersion(); version != "2020-10-08" {
+		t.Errorf("version = %s, want 2020-10-08", version)
+	}
+	if monster, ok := (Unit{UnitMasterId: 13413}).Monster(); !ok || monster.String() != "Mihyang (Wind)" {
+		t.Errorf("monster of unit 13413 = %v, %v, want Mihyang (Wind)", monster, ok)
+	}
+	if _, ok := LookupMonster(10111); ok {
+		t.Error("found monster 10111 that is only in the bundled master data")
+	}
+
+	for _, invalid := range []string{`{"families": {"fairy": {}}}`, `{"families": {"101": {"awakened": {"water": "Elucia"}}}}`, `[]`} {
+		if _, err := LoadMonsterData(strings.NewReader(invalid)); err == nil {
+			t.Errorf("LoadMonsterData(%s) succeeded", invalid)
+		}
+	}
+}
@@ -20,6 +20,19 @@ type GetWizardInfo struct {
 	WizardInfo WizardInfo `json:"wizard_info"`
 }
 
+//
+// type(ApiResponse): HubUserLogin
+type HubUserLogin struct {
+	ApiResponse
+	WizardInfo   WizardInfo `json:"wizard_info"`
+	UnitList     []Unit     `json:"unit_list"`
+	Runes        []Rune     `json:"runes"`
+	Artifacts    []Artifact `json:"artifacts"`
+	BuildingList []Building `json:"building_list"`
+	// HomunculusSkillList contains the skills of all homunculus units, see HomunculusBuilds
+	HomunculusSkillList []HomunculusSkill `json:"homunculus_skill_list"`
+}
+
 //
 // type(ApiResponse): GetWizardInfo
 type BattleDungeonStart struct {
@@ -32,7 +45,8 @@ type BattleDungeonStart struct {
 // type(ApiResponse): GetWizardInfo
 type BattleDungeonResultV2 struct {
 	ApiResponse
-	WinLose         bool                          `json:"win_lose"`
+	// WinLose was a bool, but the game sends 1 for a win and 2 for a loss. Compare it with BattleWin.
+	WinLose         BattleOutcome                 `json:"win_lose"`
 	WizardInfo      WizardInfo                    `json:"wizard_info"`
 	Reward          DungeonReward                 `json:"reward"`
 	ChangedItemList []DungeonChangedItemListEntry `json:"changed_item_list"`
@@ -49,7 +63,8 @@ type BattleTrialTowerStartV2 struct {
 // type(ApiResponse): GetWizardInfo
 type BattleTrialTowerResultV2 struct {
 	ApiResponse
-	WinLose    bool          `json:"win_lose"`
+	// WinLose was a bool, but the game sends 1 for a win and 2 for a loss. Compare it with BattleWin.
+	WinLose    BattleOutcome `json:"win_lose"`
 	WizardInfo WizardInfo    `json:"wizard_info"`
 	Reward     DungeonReward `json:"reward"`
 	FloorId    int           `json:"floor_id"`
@@ -59,6 +74,7 @@ type BattleTrialTowerResultV2 struct {
 // fixme(lyrex): i'm pretty sure this should not be a thing
 func CommandToType(command string) reflect.Type {
 	commandTypeMap := map[string]reflect.Type{
+		"HubUserLogin":              reflect.TypeOf(HubUserLogin{}),
 		"GetWizardInfo":             reflect.TypeOf(GetWizardInfo{}),
 		"BattleDungeonStart":        reflect.TypeOf(BattleDungeonStart{}),
 		"BattleDungeonResult_V2":    reflect.TypeOf(BattleDungeonResultV2{}),
@@ -0,0 +1,219 @@
+package gamemodels
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readResponse(t *testing.T, command string, v interface{}) {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", command+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decoding %s: %v", command, err)
+	}
+}
+
+func TestGetWizardInfo(t *testing.T) {
+	var resp GetWizardInfo
+	readResponse(t, "GetWizardInfo", &resp)
+
+	if resp.Command != "GetWizardInfo" || resp.TZone != "America/Los_Angeles" {
+		t.Errorf("api response = %+v", resp.ApiResponse)
+	}
+	want := WizardInfo{
+		WizardId:           12345678,
+		WizardName:         "tester",
+		WizardMana:         1518340,
+		WizardCrystal:      2610,
+		WizardLevel:        50,
+		WizardEnergy:       139,
+		EnergyMax:          115,
+		EnergyPerMin:       0.3,
+		NextEnergyGain:     54,
+		SocialPointCurrent: 2870,
+		SocialPointMax:     3000,
+	}
+	if resp.WizardInfo != want {
+		t.Errorf("wizard info = %+v, want %+v", resp.WizardInfo, want)
+	}
+}
+
+func TestHubUserLogin(t *testing.T) {
+	var resp HubUserLogin
+	readResponse(t, "HubUserLogin", &resp)
+
+	if resp.WizardInfo.WizardId != 12345678 || !resp.WizardInfo.MailBoxEvent {
+		t.Errorf("wizard info = %+v", resp.WizardInfo)
+	}
+	if len(resp.UnitList) != 2 || len(resp.Runes) != 1 || len(resp.Artifacts) != 1 || len(resp.BuildingList) != 1 {
+		t.Fatalf("login has %d units, %d runes, %d artifacts and %d buildings, want 2, 1, 1 and 1",
+			len(resp.UnitList), len(resp.Runes), len(resp.Artifacts), len(resp.BuildingList))
+	}
+
+	// unit 9000000001 was sent with its runes as array, unit 9000000002 with runes and artifacts keyed by slot
+	tests := []struct {
+		unit      Unit
+		runes     []int
+		artifacts []int
+	}{
+		{resp.UnitList[0], []int{1, 2}, nil},
+		{resp.UnitList[1], []int{4, 6}, []int{2}},
+	}
+	for _, tt := range tests {
+		var runes, artifacts []int
+		for _, r := range tt.unit.Runes {
+			if r.OccupiedId != tt.unit.UnitId {
+				t.Errorf("rune %d of unit %d is occupied by %d", r.RuneId, tt.unit.UnitId, r.OccupiedId)
+			}
+			runes = append(runes, r.Slot)
+		}
+		for _, artifact := range tt.unit.Artifacts {
+			artifacts = append(artifacts, artifact.Slot)
+		}
+		if !equalInts(runes, tt.runes) || !equalInts(artifacts, tt.artifacts) {
+			t.Errorf("unit %d has runes in slots %v and artifacts in slots %v, want %v and %v",
+				tt.unit.UnitId, runes, artifacts, tt.runes, tt.artifacts)
+		}
+	}
+
+	unit := resp.UnitList[0]
+	if unit.Stars != 6 || unit.Hp() != 754*15 || len(unit.Skills) != 3 || unit.Skills[2] != (UnitSkill{SkillId: 1343, Level: 6}) {
+		t.Errorf("unit = %+v", unit)
+	}
+
+	artifact := resp.UnitList[1].Artifacts[0]
+	if artifact.Type != ArtifactArchetype || artifact.Archetype != ArchetypeHp || artifact.MainStat.EffectId != 101 ||
+		artifact.MainStat.Upgrades != 3 || len(artifact.Substats) != 2 || artifact.Substats[1].Upgrades != 2 {
+		t.Errorf("artifact = %+v", artifact)
+	}
+}
+
+func TestHubUserLoginRunes(t *testing.T) {
+	var resp HubUserLogin
+	readResponse(t, "HubUserLogin", &resp)
+
+	runes := map[int]Rune{}
+	for _, unit := range resp.UnitList {
+		for _, r := range unit.Runes {
+			runes[r.RuneId] = r
+		}
+	}
+	for _, r := range resp.Runes {
+		runes[r.RuneId] = r
+	}
+
+	tests := []struct {
+		name       string
+		runeId     int
+		stars      int
+		ancient    bool
+		innateStat *RuneStat
+		substats   int
+	}{
+		{"ancient rune", 30000000001, 6, true, &RuneStat{EffectType: 9, EffectValue: 6}, 4},
+		{"rune without innate stat", 30000000002, 6, false, nil, 4},
+		{"slot-keyed rune", 30000000006, 5, false, &RuneStat{EffectType: 11, EffectValue: 4}, 3},
+		{"rune without substats", 30000000010, 4, false, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ok := runes[tt.runeId]
+			if !ok {
+				t.Fatalf("rune %d was not decoded", tt.runeId)
+			}
+			if r.Stars != tt.stars || r.Ancient != tt.ancient {
+				t.Errorf("rune has %d stars, ancient %v, want %d and %v", r.Stars, r.Ancient, tt.stars, tt.ancient)
+			}
+			if (r.InnateStat == nil) != (tt.innateStat == nil) || (r.InnateStat != nil && *r.InnateStat != *tt.innateStat) {
+				t.Errorf("innate stat = %+v, want %+v", r.InnateStat, tt.innateStat)
+			}
+			if len(r.Substats) != tt.substats {
+				t.Errorf("rune has %d substats, want %d", len(r.Substats), tt.substats)
+			}
+		})
+	}
+
+	// the crit rate substat of the rune without innate stat was enchanted, its hp% substat grinded
+	substats := runes[30000000002].Substats
+	if !substats[1].IsEnchanted || substats[1].IsGrinded() || !substats[3].IsGrinded() || substats[3].GrindValue != 5 {
+		t.Errorf("substats = %+v", substats)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBattleResultWinLose(t *testing.T) {
+	tests := []struct {
+		data string
+		want BattleOutcome
+	}{
+		{`{"win_lose": 1, "reward": {"mana": 1200}}`, BattleWin},
+		{`{"win_lose": 2}`, BattleLose},
+	}
+	for _, tt := range tests {
+		var dungeon BattleDungeonResultV2
+		if err := json.Unmarshal([]byte(tt.data), &dungeon); err != nil {
+			t.Fatal(err)
+		}
+		var tower BattleTrialTowerResultV2
+		if err := json.Unmarshal([]byte(tt.data), &tower); err != nil {
+			t.Fatal(err)
+		}
+		if dungeon.WinLose != tt.want || tower.WinLose != tt.want {
+			t.Errorf("win_lose of %s = %s and %s, want %s", tt.data, dungeon.WinLose, tower.WinLose, tt.want)
+		}
+	}
+
+	// results used to be decoded as bool
+	var dungeon BattleDungeonResultV2
+	if err := json.Unmarshal([]byte(`{"win_lose": true}`), &dungeon); err == nil {
+		t.Error("decoded a bool win_lose")
+	}
+}
+
+// TestRecordedSession decodes all recorded responses, they are replayed by the tests of the plugins
+func TestRecordedSession(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		if strings.HasSuffix(name, ".request") {
+			continue
+		}
+		command := strings.SplitN(name, ".", 2)[0]
+
+		resp := reflect.New(reflect.TypeOf(ApiResponse{}))
+		if typ := CommandToType(command); typ != nil {
+			resp = reflect.New(typ)
+		}
+		readResponse(t, name, resp.Interface())
+
+		api := reflect.Indirect(resp).FieldByName("ApiResponse")
+		if !api.IsValid() {
+			api = reflect.Indirect(resp)
+		}
+		if got := api.Interface().(ApiResponse); got.Command != command || got.RetCode != 0 {
+			t.Errorf("%s: command = %s, ret_code = %d, want a successful %s", name, got.Command, got.RetCode, command)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package jsonpath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is a single step of a path: an object key, an array index or a wildcard over all array elements
+type segment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// Path addresses values inside of a decoded JSON document (as produced by encoding/json), e.g.
+// `response.wizard_info.wizard_mana`, `response.unit_list[0]` or `response.unit_list[*].unit_id`.
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// Parse parses a path in dot notation with optional array indices or wildcards
+func Parse(raw string) (Path, error) {
+	if raw == "" {
+		return Path{}, errors.New("path must not be empty")
+	}
+
+	p := Path{raw: raw}
+	for _, part := range strings.Split(raw, ".") {
+		key := part
+		var indices []string
+		if idx := strings.Index(part, "["); idx >= 0 {
+			key = part[:idx]
+			rest := part[idx:]
+			for rest != "" {
+				end := strings.Index(rest, "]")
+				if rest[0] != '[' || end < 0 {
+					return Path{}, fmt.Errorf("invalid array index in path %q", raw)
+				}
+				indices = append(indices, rest[1:end])
+				rest = rest[end+1:]
+			}
+		}
+
+		if key == "" && (len(p.segments) > 0 || len(indices) == 0) {
+			return Path{}, fmt.Errorf("empty key in path %q", raw)
+		}
+		if key != "" {
+			p.segments = append(p.segments, segment{key: key})
+		}
+
+		for _, index := range indices {
+			if index == "*" {
+				p.segments = append(p.segments, segment{wildcard: true})
+				continue
+			}
+
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 {
+				return Path{}, fmt.Errorf("invalid array index %q in path %q", index, raw)
+			}
+			p.segments = append(p.segments, segment{index: i, isIndex: true})
+		}
+	}
+
+	return p, nil
+}
+
+// MustParse is like Parse but panics if the path is invalid
+func MustParse(raw string) Path {
+	p, err := Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p Path) String() string {
+	return p.raw
+}
+
+// Get returns all values addressed by the path. Wildcards can match multiple values, missing keys match none.
+func (p Path) Get(doc interface{}) []interface{} {
+	values := []interface{}{doc}
+
+	for _, seg := range p.segments {
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, seg.get(value)...)
+		}
+		values = next
+
+		if len(values) == 0 {
+			break
+		}
+	}
+
+	return values
+}
+
+func (s segment) get(value interface{}) []interface{} {
+	switch {
+	case s.wildcard:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+		return array
+	case s.isIndex:
+		array, ok := value.([]interface{})
+		if !ok || s.index >= len(array) {
+			return nil
+		}
+		return []interface{}{array[s.index]}
+	default:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		child, ok := object[s.key]
+		if !ok {
+			return nil
+		}
+		return []interface{}{child}
+	}
+}
+
+// Project returns a copy of doc that only contains the values addressed by the paths. Objects keep their
+// structure, so `response.wizard_info.wizard_mana` is projected to `{"response":{"wizard_info":{"wizard_mana":1}}}`.
+// Arrays keep their indices, elements that weren't addressed are null.
+func Project(doc interface{}, paths []Path) interface{} {
+	var projected interface{}
+	for _, p := range paths {
+		if value, ok := project(doc, p.segments); ok {
+			projected = merge(projected, value)
+		}
+	}
+	return projected
+}
+
+func project(value interface{}, segments []segment) (interface{}, bool) {
+	if len(segments) == 0 {
+		return value, true
+	}
+
+	s, rest := segments[0], segments[1:]
+	switch {
+	case s.wildcard:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		projected := make([]interface{}, len(array))
+		for i, element := range array {
+			projected[i], _ = project(element, rest)
+		}
+		return projected, true
+	case s.isIndex:
+		array, ok := value.([]interface{})
+		if !ok || s.index >= len(array) {
+			return nil, false
+		}
+
+		element, ok := project(array[s.index], rest)
+		if !ok {
+			return nil, false
+		}
+
+		projected := make([]interface{}, s.index+1)
+		projected[s.index] = element
+		return projected, true
+	default:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		child, ok := object[s.key]
+		if !ok {
+			return nil, false
+		}
+
+		projected, ok := project(child, rest)
+		if !ok {
+			return nil, false
+		}
+		return map[string]interface{}{s.key: projected}, true
+	}
+}
+
+// merge combines two projections of the same document without modifying either of them
+func merge(a, b interface{}) interface{} {
+	switch av := a.(type) {
+	case nil:
+		return b
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			return a
+		}
+
+		merged := make(map[string]interface{}, len(av)+len(bv))
+		for key, value := range av {
+			merged[key] = value
+		}
+		for key, value := range bv {
+			merged[key] = merge(merged[key], value)
+		}
+		return merged
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			return a
+		}
+		if len(bv) > len(av) {
+			av, bv = bv, av
+		}
+
+		merged := make([]interface{}, len(av))
+		copy(merged, av)
+		for i, value := range bv {
+			merged[i] = merge(merged[i], value)
+		}
+		return merged
+	}
+
+	return a
+}
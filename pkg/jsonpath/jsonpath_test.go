@@ -0,0 +1,125 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const document = `{
+	"wizard_info": {"wizard_id": 1, "wizard_mana": 1500},
+	"unit_list": [
+		{"unit_id": 100, "runes": [{"rune_id": 11}, {"rune_id": 12}]},
+		{"unit_id": 101, "runes": {"1": {"rune_id": 13}}}
+	],
+	"matrix": [[1, 2], [3]]
+}`
+
+func decode(t *testing.T, data string) interface{} {
+	t.Helper()
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"unit_list..unit_id",
+		".unit_list",
+		"unit_list.",
+		"unit_list[",
+		"unit_list[0",
+		"unit_list]0[",
+		"unit_list[x]",
+		"unit_list[-1]",
+		"unit_list[0]x",
+	}
+	for _, raw := range tests {
+		if p, err := Parse(raw); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", raw, p.segments)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	doc := decode(t, document)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"wizard_info.wizard_mana", `[1500]`},
+		{"wizard_info", `[{"wizard_id": 1, "wizard_mana": 1500}]`},
+		{"wizard_info.missing", `null`},
+		{"wizard_info.wizard_mana.missing", `null`},
+		{"unit_list[1].unit_id", `[101]`},
+		{"unit_list[2].unit_id", `null`},
+		{"unit_list[*].unit_id", `[100, 101]`},
+		{"unit_list[*].runes[*].rune_id", `[11, 12]`},
+		{"unit_list[1].runes.1.rune_id", `[13]`},
+		{"wizard_info[*]", `null`},
+		{"matrix[0][1]", `[2]`},
+		{"matrix[*][0]", `[1, 3]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := MustParse(tt.path).Get(doc)
+
+			var want []interface{}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) == 0 && len(want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Get() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	doc := decode(t, document)
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{"object", []string{"wizard_info.wizard_mana"}, `{"wizard_info": {"wizard_mana": 1500}}`},
+		{"merged objects", []string{"wizard_info.wizard_mana", "wizard_info.wizard_id"}, `{"wizard_info": {"wizard_id": 1, "wizard_mana": 1500}}`},
+		{"wildcard", []string{"unit_list[*].unit_id"}, `{"unit_list": [{"unit_id": 100}, {"unit_id": 101}]}`},
+		{"wildcard with missing values", []string{"unit_list[*].runes[0].rune_id"}, `{"unit_list": [{"runes": [{"rune_id": 11}]}, null]}`},
+		{"index", []string{"unit_list[1].unit_id"}, `{"unit_list": [null, {"unit_id": 101}]}`},
+		{"merged arrays", []string{"unit_list[1].unit_id", "unit_list[0].unit_id"}, `{"unit_list": [{"unit_id": 100}, {"unit_id": 101}]}`},
+		{"missing", []string{"wizard_info.missing"}, `null`},
+		{"missing and present", []string{"missing", "wizard_info.wizard_id"}, `{"wizard_info": {"wizard_id": 1}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []Path
+			for _, raw := range tt.paths {
+				paths = append(paths, MustParse(raw))
+			}
+
+			got := Project(doc, paths)
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Project() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestProjectDoesNotModifyTheDocument(t *testing.T) {
+	doc := decode(t, document)
+	Project(doc, []Path{MustParse("unit_list[0].unit_id"), MustParse("unit_list[*].runes")})
+
+	if want := decode(t, document); !reflect.DeepEqual(doc, want) {
+		t.Errorf("Project() modified the document: %v", doc)
+	}
+}
@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+// Event is an api event that was published by the proxy
+type Event struct {
+	Command  string
+	Request  string
+	Response string
+	// Metadata describes the HTTP exchange the event was captured from
+	Metadata events.ExchangeMetadata
+
+	// RequestData and ResponseData are the decoded payloads in the representation of encoding/json.
+	// They are only set if the plugin enabled StructuredPayload.
+	RequestData  interface{}
+	ResponseData interface{}
+	// ResponseMessage is the typed message of the response for the well-known commands, see gamemodelspb.
+	// It is only set if the plugin enabled StructuredPayload.
+	ResponseMessage proto.Message
+}
+
+// DecodeRequest unmarshals the JSON request of the event into v
+func (e Event) DecodeRequest(v interface{}) error {
+	return json.Unmarshal([]byte(e.Request), v)
+}
+
+// DecodeResponse unmarshals the JSON response of the event into v
+func (e Event) DecodeResponse(v interface{}) error {
+	return json.Unmarshal([]byte(e.Response), v)
+}
+
+// HandlerFunc handles all events of the commands it was registered for
+type HandlerFunc func(ctx context.Context, ev Event) error
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+
+	errInvalidTypedHandler = errors.New("typed handlers must be of type func(context.Context, *T) error")
+)
+
+// newTypedHandler wraps a function in the form `func(context.Context, *T) error` into a HandlerFunc
+// that decodes the response of each event into a new T before calling the function
+func newTypedHandler(handler interface{}) (HandlerFunc, error) {
+	fn := reflect.ValueOf(handler)
+	if !fn.IsValid() || fn.Kind() != reflect.Func || fn.IsNil() {
+		return nil, errInvalidTypedHandler
+	}
+
+	fnType := fn.Type()
+	if fnType.NumIn() != 2 || fnType.NumOut() != 1 ||
+		fnType.In(0) != contextType || fnType.In(1).Kind() != reflect.Ptr || fnType.Out(0) != errorType {
+		return nil, errInvalidTypedHandler
+	}
+
+	responseType := fnType.In(1).Elem()
+
+	return func(ctx context.Context, ev Event) error {
+		response := reflect.New(responseType)
+		if err := ev.DecodeResponse(response.Interface()); err != nil {
+			return fmt.Errorf("could not decode %s response into %s: %w", ev.Command, responseType, err)
+		}
+
+		result := fn.Call([]reflect.Value{reflect.ValueOf(ctx), response})[0]
+		if result.IsNil() {
+			return nil
+		}
+		return result.Interface().(error)
+	}, nil
+}
@@ -0,0 +1,53 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+func TestNewTypedHandler(t *testing.T) {
+	var nilHandler func(context.Context, *gamemodels.GetWizardInfo) error
+
+	tests := []struct {
+		name    string
+		handler interface{}
+	}{
+		{"nil", nil},
+		{"nil function", nilHandler},
+		{"no function", "GetWizardInfo"},
+		{"no pointer", func(context.Context, gamemodels.GetWizardInfo) error { return nil }},
+		{"no error", func(context.Context, *gamemodels.GetWizardInfo) {}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newTypedHandler(tt.handler); err != errInvalidTypedHandler {
+				t.Errorf("newTypedHandler() error = %v, want %v", err, errInvalidTypedHandler)
+			}
+		})
+	}
+}
+
+func TestTypedHandlerDecodesResponse(t *testing.T) {
+	var wizardId int
+	handler, err := newTypedHandler(func(ctx context.Context, resp *gamemodels.GetWizardInfo) error {
+		wizardId = resp.WizardInfo.WizardId
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev := Event{Command: "GetWizardInfo", Response: `{"wizard_info": {"wizard_id": 12345678}}`}
+	if err := handler(context.Background(), ev); err != nil {
+		t.Fatal(err)
+	}
+	if wizardId != 12345678 {
+		t.Errorf("wizard id = %d, want 12345678", wizardId)
+	}
+
+	if err := handler(context.Background(), Event{Command: "GetWizardInfo", Response: "{"}); err == nil {
+		t.Error("handler decoded an invalid response")
+	}
+}
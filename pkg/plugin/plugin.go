@@ -0,0 +1,420 @@
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/swarpf/proxy/pkg/eventfilter"
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/structuredevent"
+	pb "github.com/swarpf/proxy/swarpf-idl/proto-gen-go/proxyapi"
+)
+
+type Configuration struct {
+	// ProxyAddress is the address of the proxy api
+	ProxyAddress string `default:"127.0.0.1:11000"`
+	// ListenAddress is the address the plugin listens to for api events. The proxy connects to the IP
+	// the plugin registers from, so only the port is relevant for the registration.
+	ListenAddress string `default:"0.0.0.0:0"`
+	// Filter is an optional expression that is evaluated by the proxy, so only matching events are sent to
+	// the plugin, see eventfilter.Filter
+	Filter string
+	// Projection is an optional list of paths like `response.wizard_info`. If set, the proxy only sends these
+	// parts of the request and response to the plugin, see eventfilter.Projection
+	Projection []string
+	// StructuredPayload lets the proxy send the decoded payloads along with each event, see structuredevent
+	StructuredPayload bool
+	// Token is sent as bearer token to authenticate the plugin at the proxy api
+	Token string
+	// TLSConfig enables TLS for the connection to the proxy api
+	TLSConfig *tls.Config
+	// ServerTLSConfig enables TLS for connections of the proxy to the plugin
+	ServerTLSConfig *tls.Config
+	// MinBackoff and MaxBackoff limit the delay between failed registration attempts
+	MinBackoff time.Duration `default:"1s"`
+	MaxBackoff time.Duration `default:"30s"`
+	// CallTimeout limits the duration of each call to the proxy api
+	CallTimeout time.Duration `default:"5s"`
+}
+
+type handlerEntry struct {
+	command string
+	handler HandlerFunc
+}
+
+// Plugin is a proxy api consumer. It receives api events from the proxy and dispatches them to the
+// handlers registered for their command.
+type Plugin struct {
+	log           zerolog.Logger
+	configuration Configuration
+
+	mu       sync.RWMutex
+	handlers []handlerEntry
+}
+
+// plugin.New : Create a new plugin. Handlers have to be registered before calling Run.
+func New(configuration Configuration) *Plugin {
+	if configuration.ProxyAddress == "" {
+		configuration.ProxyAddress = "127.0.0.1:11000"
+	}
+	if configuration.ListenAddress == "" {
+		configuration.ListenAddress = "0.0.0.0:0"
+	}
+	if configuration.MinBackoff == 0 {
+		configuration.MinBackoff = time.Second
+	}
+	if configuration.MaxBackoff == 0 {
+		configuration.MaxBackoff = 30 * time.Second
+	}
+	if configuration.CallTimeout == 0 {
+		configuration.CallTimeout = 5 * time.Second
+	}
+
+	return &Plugin{
+		log:           log.With().Timestamp().Str("log_type", "module").Str("module", "Plugin").Logger(),
+		configuration: configuration,
+	}
+}
+
+// Handle registers handler for all commands matching the command glob
+func (p *Plugin) Handle(command string, handler HandlerFunc) error {
+	if _, err := path.Match(command, ""); err != nil {
+		return fmt.Errorf("invalid command glob %q: %w", command, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers = append(p.handlers, handlerEntry{command: command, handler: handler})
+
+	return nil
+}
+
+// HandleTyped registers a function in the form `func(context.Context, *T) error` for all commands matching
+// the command glob. The response of each event is decoded into a new T, e.g. gamemodels.BattleDungeonResultV2.
+func (p *Plugin) HandleTyped(command string, handler interface{}) error {
+	typedHandler, err := newTypedHandler(handler)
+	if err != nil {
+		return err
+	}
+
+	return p.Handle(command, typedHandler)
+}
+
+func (p *Plugin) commands() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	commands := make([]string, 0, len(p.handlers))
+	for _, entry := range p.handlers {
+		commands = append(commands, entry.command)
+	}
+	return commands
+}
+
+// dispatch calls all handlers matching the command of ev
+func (p *Plugin) dispatch(ctx context.Context, ev Event) error {
+	if ev.Command == events.ProxyShuttingDown {
+		// the registration is renewed as soon as the proxy is reachable again
+		p.log.Info().Msg("Proxy is shutting down")
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var failed []string
+	for _, entry := range p.handlers {
+		if matched, _ := events.MatchCommand(entry.command, ev.Command); !matched {
+			continue
+		}
+
+		if err := entry.handler(ctx, ev); err != nil {
+			p.log.Error().Err(err).
+				Str("command", ev.Command).
+				Str("handler", entry.command).
+				Msg("Handler failed to process api event")
+			failed = append(failed, err.Error())
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// Run starts the consumer server, registers the plugin at the proxy and keeps the registration alive until
+// ctx is cancelled. The plugin is deregistered from the proxy before Run returns.
+func (p *Plugin) Run(ctx context.Context) error {
+	commands := p.commands()
+	if len(commands) == 0 {
+		return errors.New("plugin has no handlers")
+	}
+
+	if p.configuration.Filter != "" {
+		if _, err := eventfilter.Compile(p.configuration.Filter); err != nil {
+			return fmt.Errorf("invalid filter: %w", err)
+		}
+	}
+	if len(p.configuration.Projection) > 0 {
+		if _, err := eventfilter.CompileProjection(p.configuration.Projection); err != nil {
+			return fmt.Errorf("invalid projection: %w", err)
+		}
+	}
+
+	lis, err := net.Listen("tcp", p.configuration.ListenAddress)
+	if err != nil {
+		return fmt.Errorf("could not listen to %s: %w", p.configuration.ListenAddress, err)
+	}
+
+	var serverOptions []grpc.ServerOption
+	if p.configuration.ServerTLSConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(p.configuration.ServerTLSConfig)))
+	}
+	server := grpc.NewServer(serverOptions...)
+	pb.RegisterProxyApiConsumerServer(server, &consumerServer{plugin: p})
+	structuredevent.RegisterServer(server, &structuredConsumerServer{plugin: p})
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(lis)
+	}()
+	defer server.GracefulStop()
+
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			p.log.Error().Err(err).Msg("Failed to close connection")
+		}
+	}()
+
+	client := pb.NewProxyApiClient(conn)
+	options := &pb.ProxyApiOptions{
+		Address:  lis.Addr().String(),
+		Commands: commands,
+	}
+
+	for {
+		if err := p.register(ctx, client, options); err != nil {
+			// ctx was cancelled before the registration succeeded
+			return nil
+		}
+
+		// re-register as soon as the connection recovers, since the proxy forgets its consumers on restart
+		if err := p.waitForReconnect(ctx, conn, serveErr); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			p.deregister(client, options)
+			return nil
+		}
+
+		p.log.Info().Msg("Connection to proxy api was re-established")
+	}
+}
+
+func (p *Plugin) dial(ctx context.Context) (*grpc.ClientConn, error) {
+	transportCredentials := grpc.WithInsecure()
+	if p.configuration.TLSConfig != nil {
+		transportCredentials = grpc.WithTransportCredentials(credentials.NewTLS(p.configuration.TLSConfig))
+	}
+
+	dialOptions := []grpc.DialOption{transportCredentials}
+	if p.configuration.Token != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(bearerToken{
+			token:  p.configuration.Token,
+			secure: p.configuration.TLSConfig != nil,
+		}))
+	}
+
+	conn, err := grpc.DialContext(ctx, p.configuration.ProxyAddress, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to proxy api at %s: %w", p.configuration.ProxyAddress, err)
+	}
+
+	return conn, nil
+}
+
+// register tries to register the plugin with exponential backoff until it succeeds or ctx is cancelled
+func (p *Plugin) register(ctx context.Context, client pb.ProxyApiClient, options *pb.ProxyApiOptions) error {
+	backoff := p.configuration.MinBackoff
+
+	for {
+		callCtx, cancel := context.WithTimeout(ctx, p.configuration.CallTimeout)
+		if p.configuration.Filter != "" {
+			callCtx = metadata.AppendToOutgoingContext(callCtx, eventfilter.FilterMetadataKey, p.configuration.Filter)
+		}
+		if p.configuration.StructuredPayload {
+			callCtx = metadata.AppendToOutgoingContext(callCtx,
+				structuredevent.PayloadMetadataKey, structuredevent.PayloadStructured)
+		}
+		for _, path := range p.configuration.Projection {
+			callCtx = metadata.AppendToOutgoingContext(callCtx, eventfilter.ProjectionMetadataKey, path)
+		}
+		_, err := client.Register(callCtx, options, grpc.WaitForReady(true))
+		cancel()
+
+		// the proxy still knows us if only our connection was interrupted
+		if err == nil || status.Code(err) == codes.AlreadyExists {
+			p.log.Info().
+				Str("proxyAddress", p.configuration.ProxyAddress).
+				Strs("commands", options.Commands).
+				Str("filter", p.configuration.Filter).
+				Msg("Successfully registered at proxy api")
+			return nil
+		}
+
+		p.log.Warn().Err(err).
+			Str("proxyAddress", p.configuration.ProxyAddress).
+			Dur("backoff", backoff).
+			Msg("Failed to register at proxy api")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > p.configuration.MaxBackoff {
+			backoff = p.configuration.MaxBackoff
+		}
+	}
+}
+
+func (p *Plugin) deregister(client pb.ProxyApiClient, options *pb.ProxyApiOptions) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.configuration.CallTimeout)
+	defer cancel()
+
+	if _, err := client.Disconnect(ctx, options); err != nil {
+		p.log.Warn().Err(err).Msg("Failed to disconnect from proxy api")
+		return
+	}
+
+	p.log.Info().Msg("Successfully disconnected from proxy api")
+}
+
+// waitForReconnect blocks until the connection to the proxy was lost and became ready again, or ctx is cancelled
+func (p *Plugin) waitForReconnect(ctx context.Context, conn *grpc.ClientConn, serveErr <-chan error) error {
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lostConnection := false
+	for {
+		// every transition away from ready means that the proxy may have dropped us, e.g. a connection that
+		// goes idle or straight back to connecting after the proxy restarted
+		state := conn.GetState()
+		switch {
+		case state == connectivity.Ready && lostConnection:
+			return nil
+		case state != connectivity.Ready:
+			lostConnection = true
+		}
+
+		stateChanged := make(chan bool, 1)
+		go func() {
+			stateChanged <- conn.WaitForStateChange(waitCtx, state)
+		}()
+
+		select {
+		case err := <-serveErr:
+			return fmt.Errorf("consumer server stopped: %w", err)
+		case changed := <-stateChanged:
+			if !changed {
+				// ctx was cancelled
+				return nil
+			}
+		}
+	}
+}
+
+// bearerToken authenticates every call to the proxy api
+type bearerToken struct {
+	token  string
+	secure bool
+}
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return t.secure
+}
+
+// consumerServer receives the api events from the proxy
+type consumerServer struct {
+	pb.UnimplementedProxyApiConsumerServer
+	plugin *Plugin
+}
+
+func (s *consumerServer) OnReceiveApiEvent(ctx context.Context, ev *pb.ApiEvent) (*pb.ProxyApiConsumerResponse, error) {
+	fields := map[string]string{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, values := range md {
+			if strings.HasPrefix(key, events.GrpcMetadataPrefix) && len(values) > 0 {
+				fields[strings.TrimPrefix(key, events.GrpcMetadataPrefix)] = values[0]
+			}
+		}
+	}
+
+	err := s.plugin.dispatch(ctx, Event{
+		Command:  ev.GetCommand(),
+		Request:  ev.GetRequest(),
+		Response: ev.GetResponse(),
+		Metadata: events.ParseExchangeMetadata(fields),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ProxyApiConsumerResponse{}, nil
+}
+
+// structuredConsumerServer receives the api events from the proxy if the plugin enabled StructuredPayload
+type structuredConsumerServer struct {
+	plugin *Plugin
+}
+
+func (s *structuredConsumerServer) OnReceiveStructuredApiEvent(ctx context.Context, ev *structpb.Struct) (*emptypb.Empty, error) {
+	msg, request, response := structuredevent.Decode(ev)
+	responseMessage, err := structuredevent.ResponseMessage(ev)
+	if err != nil {
+		s.plugin.log.Warn().Err(err).Str("command", msg.Command).Msg("Could not decode the typed response message")
+	}
+
+	err = s.plugin.dispatch(ctx, Event{
+		Command:         msg.Command,
+		Request:         msg.Request,
+		Response:        msg.Response,
+		Metadata:        msg.Metadata,
+		RequestData:     request,
+		ResponseData:    response,
+		ResponseMessage: responseMessage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,60 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/swarpf/proxy/swarpf-idl/proto-gen-go/proxyapi"
+)
+
+// registerClient answers Register with the queued errors, then with success
+type registerClient struct {
+	errs  []error
+	calls int
+}
+
+func (c *registerClient) Register(context.Context, *pb.ProxyApiOptions, ...grpc.CallOption) (*pb.ProxyApiProviderResponse, error) {
+	c.calls++
+	if len(c.errs) == 0 {
+		return &pb.ProxyApiProviderResponse{Success: true}, nil
+	}
+	err := c.errs[0]
+	c.errs = c.errs[1:]
+	return &pb.ProxyApiProviderResponse{}, err
+}
+
+func (c *registerClient) Disconnect(context.Context, *pb.ProxyApiOptions, ...grpc.CallOption) (*pb.ProxyApiProviderResponse, error) {
+	return &pb.ProxyApiProviderResponse{Success: true}, nil
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+	}{
+		{"registered", nil, 1},
+		{"already registered", []error{status.Error(codes.AlreadyExists, "proxy api client with this address already exists")}, 1},
+		// only the status code counts, not the message
+		{"retried", []error{status.Error(codes.Unavailable, "already exists"), errors.New("already exists")}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(Configuration{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+			client := &registerClient{errs: tt.errs}
+
+			if err := p.register(context.Background(), client, &pb.ProxyApiOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if client.calls != tt.wantCalls {
+				t.Errorf("register called Register %d times, want %d", client.calls, tt.wantCalls)
+			}
+		})
+	}
+}
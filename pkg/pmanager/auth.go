@@ -0,0 +1,216 @@
+package pmanager
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+// ApiToken is a bearer token that proxy api consumers use to authenticate themselves. Consumers using
+// the token only receive events for commands matching one of AllowedCommands.
+type ApiToken struct {
+	Token           string
+	AllowedCommands []string
+}
+
+// ParseApiToken parses a token in the form `<token>` or `<token>=<glob>|<glob>|...`.
+// Tokens without command globs are allowed to receive all commands.
+func ParseApiToken(value string) (ApiToken, error) {
+	token, allowedCommands := value, []string{"*"}
+	if idx := strings.LastIndex(value, "="); idx >= 0 {
+		token, allowedCommands = value[:idx], strings.Split(value[idx+1:], "|")
+	}
+
+	if token == "" {
+		return ApiToken{}, errors.New("api token must not be empty")
+	}
+
+	for _, command := range allowedCommands {
+		if _, err := path.Match(command, ""); err != nil {
+			return ApiToken{}, errors.New("invalid command glob " + command)
+		}
+	}
+
+	return ApiToken{Token: token, AllowedCommands: allowedCommands}, nil
+}
+
+// apiCredentials describe how a proxy api caller authenticated itself
+type apiCredentials struct {
+	// Token is the bearer token of the caller, if it used one
+	Token string
+	// ClientCertificate is set if the caller presented a verified client certificate
+	ClientCertificate bool
+	AllowedCommands   []string
+}
+
+type apiCredentialsKey struct{}
+
+// authenticate checks the credentials of a proxy api call and returns the commands the caller may receive.
+// Callers authenticate with a bearer token or a client certificate that was verified during the TLS handshake.
+// If neither tokens nor client certificates are configured the proxy api is open to everyone.
+func (pm *ProxyManager) authenticate(ctx context.Context) (apiCredentials, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, auth := range md.Get("authorization") {
+			const prefix = "Bearer "
+			if !strings.HasPrefix(auth, prefix) {
+				continue
+			}
+
+			if apiToken, ok := pm.findToken(auth[len(prefix):]); ok {
+				return apiCredentials{Token: apiToken.Token, AllowedCommands: apiToken.AllowedCommands}, nil
+			}
+			return apiCredentials{}, status.Error(codes.Unauthenticated, "invalid api token")
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+			return apiCredentials{ClientCertificate: true, AllowedCommands: []string{"*"}}, nil
+		}
+	}
+
+	if pm.requiresCredentials() {
+		return apiCredentials{}, status.Error(codes.Unauthenticated, "missing api credentials")
+	}
+
+	return apiCredentials{AllowedCommands: []string{"*"}}, nil
+}
+
+// findToken looks up the configured api token in constant time
+func (pm *ProxyManager) findToken(token string) (ApiToken, bool) {
+	pm.configurationMu.RLock()
+	defer pm.configurationMu.RUnlock()
+
+	for _, apiToken := range pm.configuration.Tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(apiToken.Token)) == 1 {
+			return apiToken, true
+		}
+	}
+	return ApiToken{}, false
+}
+
+func (pm *ProxyManager) requiresClientCertificates() bool {
+	return pm.configuration.TLSConfig != nil && pm.configuration.TLSConfig.ClientCAs != nil
+}
+
+// requiresCredentials checks if callers have to present a token or a client certificate
+func (pm *ProxyManager) requiresCredentials() bool {
+	pm.configurationMu.RLock()
+	defer pm.configurationMu.RUnlock()
+
+	return len(pm.configuration.Tokens) > 0 || pm.requiresClientCertificates()
+}
+
+// UpdateTokens replaces the api tokens at runtime. Registered consumers get the allowed commands of the new
+// version of their token, consumers whose credentials are no longer valid are removed.
+func (pm *ProxyManager) UpdateTokens(tokens []ApiToken) {
+	pm.configurationMu.Lock()
+	pm.configuration.Tokens = tokens
+	pm.configurationMu.Unlock()
+
+	requiresCredentials := pm.requiresCredentials()
+
+	activeProxyConsumersMu.Lock()
+	defer activeProxyConsumersMu.Unlock()
+
+	for consumerAddr, consumer := range activeProxyConsumers {
+		switch {
+		case consumer.Credentials.Token != "":
+			apiToken, ok := pm.findToken(consumer.Credentials.Token)
+			if !ok {
+				delete(activeProxyConsumers, consumerAddr)
+				proxyApiLogger.Warn().Str("consumerAddr", consumerAddr).
+					Msg("Removed proxy api consumer since its token was revoked")
+				continue
+			}
+
+			consumer.Credentials.AllowedCommands = apiToken.AllowedCommands
+			activeProxyConsumers[consumerAddr] = consumer
+		case !consumer.Credentials.ClientCertificate && requiresCredentials:
+			delete(activeProxyConsumers, consumerAddr)
+			proxyApiLogger.Warn().Str("consumerAddr", consumerAddr).
+				Msg("Removed proxy api consumer since it registered without credentials")
+		}
+	}
+
+	proxyApiLogger.Info().Int("tokens", len(tokens)).Msg("Applied updated proxy api tokens")
+}
+
+// authInterceptor rejects unauthenticated calls and passes the credentials of the caller on to the handler
+func (pm *ProxyManager) authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	creds, err := pm.authenticate(ctx)
+	if err != nil {
+		p, _ := peer.FromContext(ctx)
+		proxyApiLogger.Warn().Err(err).
+			Str("method", info.FullMethod).
+			Interface("peer", p.Addr).
+			Msg("Rejected unauthenticated proxy api call")
+		return nil, err
+	}
+
+	if command, ok := pm.methodCommands[info.FullMethod]; ok && !allowsMethod(creds.AllowedCommands, command) {
+		proxyApiLogger.Warn().
+			Str("method", info.FullMethod).
+			Strs("allowedCommands", creds.AllowedCommands).
+			Msg("Rejected proxy api call that is not covered by the allowed commands")
+		return nil, status.Error(codes.PermissionDenied, "the api credentials do not allow calling "+info.FullMethod)
+	}
+
+	return handler(context.WithValue(ctx, apiCredentialsKey{}, creds), req)
+}
+
+// allowsMethod checks if callers that may receive allowedCommands may call a method that requires command. Methods
+// without a command require that all commands are allowed.
+func allowsMethod(allowedCommands []string, command string) bool {
+	if command != "" {
+		return matchesAny(allowedCommands, command)
+	}
+
+	for _, glob := range allowedCommands {
+		if glob == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func credentialsFromContext(ctx context.Context) apiCredentials {
+	creds, ok := ctx.Value(apiCredentialsKey{}).(apiCredentials)
+	if !ok {
+		return apiCredentials{AllowedCommands: []string{"*"}}
+	}
+	return creds
+}
+
+// matchesAny checks if command matches at least one of the globs. Unlike subscriptions, allowed commands
+// are not namespace aware, so `*` allows all commands.
+func matchesAny(globs []string, command string) bool {
+	for _, glob := range globs {
+		if matched, _ := path.Match(glob, command); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// subscribedTo checks if one of the subscribed command globs matches command, see events.MatchCommand
+func subscribedTo(globs []string, command string) bool {
+	for _, glob := range globs {
+		if matched, _ := events.MatchCommand(glob, command); matched {
+			return true
+		}
+	}
+	return false
+}
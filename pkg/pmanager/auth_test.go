@@ -0,0 +1,78 @@
+package pmanager
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseApiToken(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    ApiToken
+		wantErr bool
+	}{
+		{"secret", ApiToken{Token: "secret", AllowedCommands: []string{"*"}}, false},
+		{"secret=Battle*|HubUserLogin", ApiToken{Token: "secret", AllowedCommands: []string{"Battle*", "HubUserLogin"}}, false},
+		{"=HubUserLogin", ApiToken{}, true},
+		{"secret=[", ApiToken{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseApiToken(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseApiToken(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got.Token != tt.want.Token || len(got.AllowedCommands) != len(tt.want.AllowedCommands) {
+			t.Errorf("ParseApiToken(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAuthInterceptorServiceCommands(t *testing.T) {
+	proxyApiLogger = zerolog.Nop()
+
+	pm := &ProxyManager{
+		configuration: ProxyApiConfiguration{Tokens: []ApiToken{
+			{Token: "all", AllowedCommands: []string{"*"}},
+			{Token: "login", AllowedCommands: []string{"HubUserLogin"}},
+			{Token: "dungeon", AllowedCommands: []string{"BattleDungeonResult_V2"}},
+		}},
+		methodCommands: map[string]string{
+			"/swarpf.proxyapi.AccountState/GetAccountState": "HubUserLogin",
+			"/swarpf.proxyapi.SchemaDrift/GetSchemaDrift":   "",
+		},
+	}
+
+	tests := []struct {
+		token  string
+		method string
+		want   codes.Code
+	}{
+		{"all", "/swarpf.proxyapi.AccountState/GetAccountState", codes.OK},
+		{"login", "/swarpf.proxyapi.AccountState/GetAccountState", codes.OK},
+		{"dungeon", "/swarpf.proxyapi.AccountState/GetAccountState", codes.PermissionDenied},
+		{"all", "/swarpf.proxyapi.SchemaDrift/GetSchemaDrift", codes.OK},
+		{"login", "/swarpf.proxyapi.SchemaDrift/GetSchemaDrift", codes.PermissionDenied},
+		// methods of the proxy api itself are filtered by command when events are delivered
+		{"dungeon", "/swarpf.proxyapi.ProxyApi/RegisterConsumer", codes.OK},
+		{"unknown", "/swarpf.proxyapi.AccountState/GetAccountState", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+		_, err := pm.authInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+			func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("token %s calling %s: got %v, want %v", tt.token, tt.method, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package pmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/swarpf/proxy/pkg/structuredevent"
+)
+
+// The plugin metrics service is always served next to the proxy api, so the health of the in-process plugins
+// can be monitored. Like the other additional services it only uses well-known protobuf types:
+//
+//	service PluginMetrics {
+//	  rpc GetPluginMetrics(google.protobuf.Empty) returns (google.protobuf.Struct);
+//	}
+//
+// The struct maps the plugin names to the json encoding of their PluginMetrics. It can only be called by
+// callers that are allowed to receive all commands.
+const (
+	PluginMetricsServiceName = "swarpf.proxyapi.PluginMetrics"
+	PluginMetricsMethodName  = "GetPluginMetrics"
+	PluginMetricsFullMethod  = "/" + PluginMetricsServiceName + "/" + PluginMetricsMethodName
+)
+
+type pluginMetricsServer interface {
+	GetPluginMetrics(ctx context.Context, in *emptypb.Empty) (*structpb.Struct, error)
+}
+
+var pluginMetricsServiceDesc = grpc.ServiceDesc{
+	ServiceName: PluginMetricsServiceName,
+	HandlerType: (*pluginMetricsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: PluginMetricsMethodName,
+			Handler:    handleGetPluginMetrics,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func handleGetPluginMetrics(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(pluginMetricsServer).GetPluginMetrics(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginMetricsFullMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(pluginMetricsServer).GetPluginMetrics(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func (pm *ProxyManager) GetPluginMetrics(context.Context, *emptypb.Empty) (*structpb.Struct, error) {
+	data, err := json.Marshal(pm.PluginMetrics())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return structuredevent.ToValue(decoded).GetStructValue(), nil
+}
+
+// PluginMetricsClient queries the metrics of the in-process plugins from the proxy
+type PluginMetricsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPluginMetricsClient(cc *grpc.ClientConn) *PluginMetricsClient {
+	return &PluginMetricsClient{cc: cc}
+}
+
+func (c *PluginMetricsClient) GetPluginMetrics(ctx context.Context, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, PluginMetricsFullMethod, &emptypb.Empty{}, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
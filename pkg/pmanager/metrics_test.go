@@ -0,0 +1,59 @@
+package pmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+type failingPlugin struct{}
+
+func (failingPlugin) Name() string       { return "failing" }
+func (failingPlugin) Commands() []string { return []string{"*"} }
+func (failingPlugin) Handle(context.Context, events.ApiEventMsg) error {
+	return errors.New("failed")
+}
+
+func TestGetPluginMetrics(t *testing.T) {
+	pm := NewProxyManager("127.0.0.1:0", ProxyApiConfiguration{})
+
+	if command, ok := pm.methodCommands[PluginMetricsFullMethod]; !ok || command != "" {
+		t.Errorf("plugin metrics require command %q (%v), want all commands", command, ok)
+	}
+
+	if err := pm.RegisterPlugin(failingPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	defer func() { _ = pm.Shutdown(ctx) }()
+
+	pm.Publish("HubUserLogin", events.ApiEventMsg{Command: "HubUserLogin", Request: "{}", Response: "{}"})
+	for pm.PluginMetrics()["failing"].Handled == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatal("the plugin did not handle the event")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	metrics, err := pm.GetPluginMetrics(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failing := metrics.Fields["failing"].GetStructValue()
+	if failing == nil {
+		t.Fatalf("GetPluginMetrics() = %v, want metrics of the failing plugin", metrics)
+	}
+	if failed := failing.Fields["failed"].GetNumberValue(); failed != 1 {
+		t.Errorf("failed = %v, want 1", failed)
+	}
+	if lastError := failing.Fields["last_error"].GetStringValue(); lastError != "failed" {
+		t.Errorf("last_error = %q, want failed", lastError)
+	}
+}
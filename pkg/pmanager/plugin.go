@@ -0,0 +1,248 @@
+package pmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/eventfilter"
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+const (
+	pluginQueueSize     = 256
+	pluginHandleTimeout = 10 * time.Second
+)
+
+// Plugin is an api event handler that runs inside of the proxy process. It receives all events whose command
+// matches one of the command globs returned by Commands, in the order they were published.
+type Plugin interface {
+	Name() string
+	Commands() []string
+	Handle(ctx context.Context, ev events.ApiEventMsg) error
+}
+
+// FilteredPlugin is a Plugin that only receives events matching its filter expression, see eventfilter.Filter
+type FilteredPlugin interface {
+	Plugin
+	Filter() string
+}
+
+// PluginMetrics describes how a plugin processed its events
+type PluginMetrics struct {
+	Handled       uint64        `json:"handled"`
+	Failed        uint64        `json:"failed"`
+	Panicked      uint64        `json:"panicked"`
+	Dropped       uint64        `json:"dropped"`
+	TotalDuration time.Duration `json:"total_duration_ns"`
+	LastError     string        `json:"last_error"`
+}
+
+type registeredPlugin struct {
+	plugin   Plugin
+	commands []string
+	filter   *eventfilter.Filter
+	queue    chan events.ApiEventMsg
+
+	mu      sync.Mutex
+	metrics PluginMetrics
+}
+
+// RegisterPlugin adds an in-process plugin. Plugins should be registered before the proxy starts publishing events.
+func (pm *ProxyManager) RegisterPlugin(plugin Plugin) error {
+	commands := plugin.Commands()
+	for _, command := range commands {
+		if _, err := path.Match(command, ""); err != nil {
+			return fmt.Errorf("plugin %s has an invalid command glob %q: %w", plugin.Name(), command, err)
+		}
+	}
+
+	var filter *eventfilter.Filter
+	if fp, ok := plugin.(FilteredPlugin); ok && fp.Filter() != "" {
+		var err error
+		if filter, err = eventfilter.Compile(fp.Filter()); err != nil {
+			return fmt.Errorf("plugin %s has an invalid filter: %w", plugin.Name(), err)
+		}
+	}
+
+	pm.pluginsMu.Lock()
+	defer pm.pluginsMu.Unlock()
+
+	for _, rp := range pm.plugins {
+		if rp.plugin.Name() == plugin.Name() {
+			return errors.New("a plugin with the name " + plugin.Name() + " is already registered")
+		}
+	}
+
+	rp := &registeredPlugin{
+		plugin:   plugin,
+		commands: commands,
+		filter:   filter,
+		queue:    make(chan events.ApiEventMsg, pluginQueueSize),
+	}
+	pm.plugins = append(pm.plugins, rp)
+
+	pm.pluginsWg.Add(1)
+	go func() {
+		defer pm.pluginsWg.Done()
+		for ev := range rp.queue {
+			rp.handle(ev)
+		}
+	}()
+
+	proxyApiLogger.Info().
+		Str("plugin", plugin.Name()).
+		Strs("commands", commands).
+		Str("filter", filter.String()).
+		Msg("Registered in-process plugin")
+
+	return nil
+}
+
+// PluginMetrics returns the metrics of all in-process plugins by their name
+func (pm *ProxyManager) PluginMetrics() map[string]PluginMetrics {
+	pm.pluginsMu.RLock()
+	defer pm.pluginsMu.RUnlock()
+
+	metrics := make(map[string]PluginMetrics, len(pm.plugins))
+	for _, rp := range pm.plugins {
+		rp.mu.Lock()
+		metrics[rp.plugin.Name()] = rp.metrics
+		rp.mu.Unlock()
+	}
+	return metrics
+}
+
+// publishToPlugins queues msg for all plugins that subscribed to its command
+func (pm *ProxyManager) publishToPlugins(msg events.ApiEventMsg, doc *eventfilter.Document) {
+	pm.pluginsMu.RLock()
+	defer pm.pluginsMu.RUnlock()
+
+	for _, rp := range pm.plugins {
+		if !subscribedTo(rp.commands, msg.Command) || (rp.filter != nil && !rp.filter.Match(doc)) {
+			continue
+		}
+
+		rp.enqueue(msg)
+	}
+}
+
+// stopPlugins waits until all plugins processed their queued events or ctx is done
+func (pm *ProxyManager) stopPlugins(ctx context.Context) error {
+	pm.pluginsMu.Lock()
+	plugins := pm.plugins
+	pm.plugins = nil
+	for _, rp := range plugins {
+		close(rp.queue)
+	}
+	pm.pluginsMu.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		pm.pluginsWg.Wait()
+		close(stopped)
+	}()
+
+	var err error
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	for _, rp := range plugins {
+		rp.mu.Lock()
+		metrics := rp.metrics
+		rp.mu.Unlock()
+
+		proxyApiLogger.Info().
+			Str("plugin", rp.plugin.Name()).
+			Uint64("handled", metrics.Handled).
+			Uint64("failed", metrics.Failed).
+			Uint64("panicked", metrics.Panicked).
+			Uint64("dropped", metrics.Dropped).
+			Int("pending", len(rp.queue)).
+			Dur("totalDuration", metrics.TotalDuration).
+			Msg("Stopped in-process plugin")
+	}
+
+	return err
+}
+
+// enqueue queues msg without blocking. Events are dropped if the plugin can't keep up, so a slow plugin does
+// not block the proxy.
+func (rp *registeredPlugin) enqueue(msg events.ApiEventMsg) {
+	select {
+	case rp.queue <- msg:
+	default:
+		rp.mu.Lock()
+		rp.metrics.Dropped++
+		rp.mu.Unlock()
+
+		proxyApiLogger.Warn().
+			Str("plugin", rp.plugin.Name()).
+			Str("msg.Command", msg.Command).
+			Msg("Plugin queue is full, dropping api event")
+	}
+}
+
+// enqueueWait queues msg and waits for space in the queue until ctx is done, for events that must not be dropped
+func (rp *registeredPlugin) enqueueWait(ctx context.Context, msg events.ApiEventMsg) {
+	select {
+	case rp.queue <- msg:
+	case <-ctx.Done():
+		rp.mu.Lock()
+		rp.metrics.Dropped++
+		rp.mu.Unlock()
+
+		proxyApiLogger.Warn().
+			Str("plugin", rp.plugin.Name()).
+			Str("msg.Command", msg.Command).
+			Msg("Plugin queue is still full, dropping api event")
+	}
+}
+
+func (rp *registeredPlugin) handle(ev events.ApiEventMsg) {
+	start := time.Now()
+	panicked, err := rp.safeHandle(ev)
+	duration := time.Since(start)
+
+	rp.mu.Lock()
+	rp.metrics.Handled++
+	rp.metrics.TotalDuration += duration
+	if err != nil {
+		rp.metrics.Failed++
+		rp.metrics.LastError = err.Error()
+	}
+	if panicked {
+		rp.metrics.Panicked++
+	}
+	rp.mu.Unlock()
+
+	if err != nil {
+		proxyApiLogger.Error().Err(err).
+			Str("plugin", rp.plugin.Name()).
+			Str("msg.Command", ev.Command).
+			Bool("panicked", panicked).
+			Msg("Plugin failed to handle api event")
+	}
+}
+
+// safeHandle isolates the proxy from panics inside of the plugin
+func (rp *registeredPlugin) safeHandle(ev events.ApiEventMsg) (panicked bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			err = fmt.Errorf("plugin panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginHandleTimeout)
+	defer cancel()
+
+	return false, rp.plugin.Handle(ctx, ev)
+}
@@ -0,0 +1,68 @@
+package pmanager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/eventfilter"
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+// blockingPlugin handles its events once release is closed
+type blockingPlugin struct {
+	release chan struct{}
+
+	mu       sync.Mutex
+	commands []string
+}
+
+func (p *blockingPlugin) Name() string       { return "blocking" }
+func (p *blockingPlugin) Commands() []string { return []string{"*"} }
+func (p *blockingPlugin) Handle(_ context.Context, ev events.ApiEventMsg) error {
+	<-p.release
+
+	p.mu.Lock()
+	p.commands = append(p.commands, ev.Command)
+	p.mu.Unlock()
+	return nil
+}
+
+func TestShutdownEventIsNotDropped(t *testing.T) {
+	pm := &ProxyManager{}
+	plugin := &blockingPlugin{release: make(chan struct{})}
+	if err := pm.RegisterPlugin(plugin); err != nil {
+		t.Fatal(err)
+	}
+
+	// the plugin blocks on the first event, the others fill its queue
+	msg := events.ApiEventMsg{Command: "HubUserLogin", Request: "{}", Response: "{}"}
+	doc := eventfilter.NewDocument(msg)
+	for i := 0; i <= pluginQueueSize+1; i++ {
+		pm.publishToPlugins(msg, doc)
+	}
+	if dropped := pm.PluginMetrics()["blocking"].Dropped; dropped == 0 {
+		t.Fatal("the queue of the plugin is not full")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	go func() {
+		pm.notifyShutdown(ctx)
+		shutdown <- pm.stopPlugins(ctx)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	close(plugin.release)
+	if err := <-shutdown; err != nil {
+		t.Fatal(err)
+	}
+
+	plugin.mu.Lock()
+	defer plugin.mu.Unlock()
+	if last := plugin.commands[len(plugin.commands)-1]; last != events.ProxyShuttingDown {
+		t.Errorf("last event = %s, want %s", last, events.ProxyShuttingDown)
+	}
+}
@@ -2,39 +2,137 @@ package pmanager
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
-	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/swarpf/proxy/pkg/apiemitter"
+	"github.com/swarpf/proxy/pkg/eventfilter"
 	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/structuredevent"
 	pb "github.com/swarpf/proxy/swarpf-idl/proto-gen-go/proxyapi"
 )
 
 type proxyConsumer struct {
-	Commands []string
-	Client   pb.ProxyApiConsumerClient
+	Commands    []string
+	Credentials apiCredentials
+	Filter      *eventfilter.Filter
+	Projection  *eventfilter.Projection
+	Client      pb.ProxyApiConsumerClient
+	// StructuredClient is set if the consumer registered for structured payloads
+	StructuredClient *structuredevent.Client
+}
+
+// deliver sends msg to the consumer in the payload format it registered for
+func (c proxyConsumer) deliver(ctx context.Context, msg events.ApiEventMsg, doc *eventfilter.Document) error {
+	if c.Projection != nil {
+		msg = c.Projection.Apply(doc)
+		doc = eventfilter.NewDocument(msg)
+	}
+
+	if c.StructuredClient != nil {
+		request, response := doc.Payloads()
+		_, err := c.StructuredClient.OnReceiveStructuredApiEvent(ctx, structuredevent.New(msg, request, response))
+		return err
+	}
+
+	// pb.ApiEvent has no fields for the exchange metadata, so it is sent as grpc metadata
+	md := metadata.MD{}
+	for key, value := range msg.Metadata.Strings() {
+		md.Set(events.GrpcMetadataPrefix+key, value)
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.Client.OnReceiveApiEvent(ctx, &pb.ApiEvent{Command: msg.Command, Request: msg.Request, Response: msg.Response})
+	return err
 }
 
 var activeProxyConsumers map[string]proxyConsumer
+var activeProxyConsumersMu sync.RWMutex
 var proxyApiLogger zerolog.Logger
 
+type ProxyApiConfiguration struct {
+	// TLSConfig enables TLS for the proxy api. Consumers have to present a client certificate if ClientCAs is set.
+	TLSConfig *tls.Config
+	// ConsumerTLSConfig enables TLS for connections to the proxy api consumers
+	ConsumerTLSConfig *tls.Config
+	// Tokens that consumers can use to authenticate themselves
+	Tokens []ApiToken
+	// Services are additional grpc services served next to the proxy api, e.g. the account state service.
+	// They use the same authentication as the proxy api.
+	Services []Service
+}
+
+// Service is an additional grpc service of the proxy api
+type Service struct {
+	Register func(s *grpc.Server)
+	// Commands maps full method names (`/<service>/<method>`) to the command a caller has to be allowed to
+	// receive to call the method. Methods without a command can only be called by callers that are allowed to
+	// receive all commands, so tokens restricted to a few commands can't read e.g. the whole account state.
+	Commands map[string]string
+}
+
 type ProxyManager struct {
 	em *apiemitter.Emitter
+
+	configurationMu sync.RWMutex
+	configuration   ProxyApiConfiguration
+	server          *grpc.Server
+	// methodCommands are the commands required to call the methods of the additional services, see Service
+	methodCommands map[string]string
+
+	pluginsMu sync.RWMutex
+	pluginsWg sync.WaitGroup
+	plugins   []*registeredPlugin
 }
 
-func NewProxyManager(proxyApiAddr string) *ProxyManager {
+func NewProxyManager(proxyApiAddr string, configuration ProxyApiConfiguration) *ProxyManager {
 	activeProxyConsumers = make(map[string]proxyConsumer)
 	proxyApiLogger = log.With().Timestamp().Str("log_type", "module").Str("module", "ProxyAPI").Logger()
 
+	pm := &ProxyManager{em: apiemitter.New(1), configuration: configuration}
+
+	serverOptions := []grpc.ServerOption{grpc.UnaryInterceptor(pm.authInterceptor)}
+	if configuration.TLSConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(configuration.TLSConfig)))
+	}
+	pm.server = grpc.NewServer(serverOptions...)
+	pb.RegisterProxyApiServer(pm.server, &proxyApiServer{pm: pm})
+	pm.methodCommands = map[string]string{}
+	// all services registered after the proxy api are restricted by methodCommands
+	proxyApiServices := pm.server.GetServiceInfo()
+	pm.server.RegisterService(&pluginMetricsServiceDesc, pm)
+	for _, service := range configuration.Services {
+		service.Register(pm.server)
+		for fullMethod, command := range service.Commands {
+			pm.methodCommands[fullMethod] = command
+		}
+	}
+	for serviceName, info := range pm.server.GetServiceInfo() {
+		if _, ok := proxyApiServices[serviceName]; ok {
+			continue
+		}
+		for _, method := range info.Methods {
+			fullMethod := "/" + serviceName + "/" + method.Name
+			if _, ok := pm.methodCommands[fullMethod]; !ok {
+				pm.methodCommands[fullMethod] = ""
+			}
+		}
+	}
+
 	go func() {
 		// initialize proxy consumer
 		lis, err := net.Listen("tcp", proxyApiAddr)
@@ -42,26 +140,44 @@ func NewProxyManager(proxyApiAddr string) *ProxyManager {
 			proxyApiLogger.Fatal().Err(err).Msg("failed to create listener")
 		}
 
-		s := grpc.NewServer()
-		pb.RegisterProxyApiServer(s, &proxyApiServer{})
-
 		proxyApiLogger.Info().
 			Str("proxyApiAddr", proxyApiAddr).
+			Bool("tls", configuration.TLSConfig != nil).
+			Bool("clientCertificates", pm.requiresClientCertificates()).
+			Int("tokens", len(configuration.Tokens)).
 			Msgf("Listening for new connections at %s", proxyApiAddr)
 
-		err = s.Serve(lis)
+		err = pm.server.Serve(lis)
 		proxyApiLogger.Info().Err(err).Msg("stopped listening for new proxy api connections")
 	}()
 
-	return &ProxyManager{em: apiemitter.New(1)}
+	return pm
 }
 
 func (pm *ProxyManager) Publish(topic string, msg events.ApiEventMsg) {
 	go pm.em.Emit(topic, msg)
 
+	// the event is only decoded if a filter is evaluated against it
+	doc := eventfilter.NewDocument(msg)
+	pm.publishToPlugins(msg, doc)
+
+	activeProxyConsumersMu.RLock()
+	defer activeProxyConsumersMu.RUnlock()
+
 	for consumerAddr, consumer := range activeProxyConsumers {
+		if !matchesAny(consumer.Credentials.AllowedCommands, msg.Command) {
+			continue
+		}
+		if consumer.Filter != nil && subscribedTo(consumer.Commands, msg.Command) && !consumer.Filter.Match(doc) {
+			proxyApiLogger.Debug().
+				Str("consumerAddr", consumerAddr).
+				Str("msg.Command", msg.Command).
+				Msg("Api event did not match the filter of the consumer")
+			continue
+		}
+
 		for _, command := range consumer.Commands {
-			if matched, err := path.Match(command, msg.Command); err != nil {
+			if matched, err := events.MatchCommand(command, msg.Command); err != nil {
 				proxyApiLogger.Error().Err(err).
 					Str("command", command).
 					Str("msg.Command", msg.Command).
@@ -71,8 +187,7 @@ func (pm *ProxyManager) Publish(topic string, msg events.ApiEventMsg) {
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-			_, err := consumer.Client.OnReceiveApiEvent(ctx,
-				&pb.ApiEvent{Command: msg.Command, Request: msg.Request, Response: msg.Response})
+			err := consumer.deliver(ctx, msg, doc)
 
 			if err != nil {
 				proxyApiLogger.Error().Err(err).Str("consumerAddr", consumerAddr).
@@ -100,14 +215,61 @@ func (pm *ProxyManager) Unsubscribe(topic string, ch ...<-chan apiemitter.Event)
 	pm.em.Off(topic, ch...)
 }
 
-func (pm *ProxyManager) Shutdown() {
+// Shutdown notifies all consumers and plugins that the proxy is shutting down, waits until the plugins processed
+// their queued events and stops the proxy api. It stops waiting once ctx is done.
+func (pm *ProxyManager) Shutdown(ctx context.Context) error {
 	pm.em.Off("*")
+	pm.notifyShutdown(ctx)
+
+	err := pm.stopPlugins(ctx)
+
+	stopped := make(chan struct{})
+	go func() {
+		pm.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		pm.server.Stop()
+		err = ctx.Err()
+	}
+
+	return err
+}
+
+// notifyShutdown sends events.ProxyShuttingDown to all consumers and plugins. Plugins receive it after all
+// events that are still queued, it is only dropped if their queue is still full once ctx is done.
+func (pm *ProxyManager) notifyShutdown(ctx context.Context) {
+	msg := events.ApiEventMsg{Command: events.ProxyShuttingDown, Request: "{}", Response: "{}"}
+	doc := eventfilter.NewDocument(msg)
+
+	pm.pluginsMu.RLock()
+	plugins := pm.plugins
+	pm.pluginsMu.RUnlock()
+	for _, rp := range plugins {
+		rp.enqueueWait(ctx, msg)
+	}
+
+	activeProxyConsumersMu.RLock()
+	defer activeProxyConsumersMu.RUnlock()
+
+	for consumerAddr, consumer := range activeProxyConsumers {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		if err := consumer.deliver(ctx, msg, doc); err != nil {
+			proxyApiLogger.Warn().Err(err).Str("consumerAddr", consumerAddr).
+				Msg("Failed to notify proxy api consumer about the shutdown")
+		}
+		cancel()
+	}
 }
 
 // proxy api provider server
 // ProxyApiProvider server implementation
 type proxyApiServer struct {
 	pb.UnimplementedProxyApiServer
+	pm *ProxyManager
 }
 
 func getIpParts(addr string) (string, string) {
@@ -130,16 +292,35 @@ func (s *proxyApiServer) Register(ctx context.Context, opts *pb.ProxyApiOptions)
 
 	proxyApiLogger.Debug().Str("remoteAddr", opts.Address).Msg("Connecting using corrected IP address")
 
+	filter, err := filterFromContext(ctx)
+	if err != nil {
+		proxyApiLogger.Warn().Err(err).Str("remoteAddr", opts.Address).Msg("Proxy api client sent an invalid filter")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter expression: %v", err)
+	}
+
+	projection, err := projectionFromContext(ctx)
+	if err != nil {
+		proxyApiLogger.Warn().Err(err).Str("remoteAddr", opts.Address).Msg("Proxy api client sent an invalid projection")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid projection: %v", err)
+	}
+
+	activeProxyConsumersMu.RLock()
 	_, exists := activeProxyConsumers[opts.Address]
+	activeProxyConsumersMu.RUnlock()
 	if exists {
 		proxyApiLogger.Warn().Str("remoteAddr", opts.Address).
 			Msg("Proxy api client with this address already exists")
 
-		err := errors.New("proxy api client with this address already exists")
+		err := status.Error(codes.AlreadyExists, "proxy api client with this address already exists")
 		return &pb.ProxyApiProviderResponse{Success: false, Error: err.Error()}, err
 	}
 
-	conn, err := grpc.Dial(opts.Address, grpc.WithInsecure(), grpc.WithBlock())
+	transportCredentials := grpc.WithInsecure()
+	if s.pm.configuration.ConsumerTLSConfig != nil {
+		transportCredentials = grpc.WithTransportCredentials(credentials.NewTLS(s.pm.configuration.ConsumerTLSConfig))
+	}
+
+	conn, err := grpc.Dial(opts.Address, transportCredentials, grpc.WithBlock())
 	if err != nil {
 		proxyApiLogger.Error().Err(err).Str("remoteAddr", opts.Address).Msg("did not connect")
 		return nil, fmt.Errorf("failed to connect to %s", opts.Address)
@@ -150,20 +331,84 @@ func (s *proxyApiServer) Register(ctx context.Context, opts *pb.ProxyApiOptions)
 	// 	}
 	// }()
 
-	c := pb.NewProxyApiConsumerClient(conn)
-	activeProxyConsumers[opts.Address] = proxyConsumer{
-		Commands: opts.Commands,
-		Client:   c,
+	creds := credentialsFromContext(ctx)
+
+	consumer := proxyConsumer{
+		Commands:    opts.Commands,
+		Credentials: creds,
+		Filter:      filter,
+		Projection:  projection,
+		Client:      pb.NewProxyApiConsumerClient(conn),
+	}
+	if structuredPayloadFromContext(ctx) {
+		consumer.StructuredClient = structuredevent.NewClient(conn)
 	}
 
+	activeProxyConsumersMu.Lock()
+	activeProxyConsumers[opts.Address] = consumer
+	activeProxyConsumersMu.Unlock()
+
 	proxyApiLogger.Info().
 		Str("consumerAddr", opts.Address).
 		Strs("commands", opts.Commands).
+		Strs("allowedCommands", creds.AllowedCommands).
+		Str("filter", filter.String()).
+		Str("projection", projection.String()).
+		Bool("structured", consumer.StructuredClient != nil).
 		Msg("Successfully registered a proxy api consumer")
 
 	return &pb.ProxyApiProviderResponse{Success: true}, nil
 }
 
+// filterFromContext compiles the optional filter expression a consumer sent along with its registration
+func filterFromContext(ctx context.Context) (*eventfilter.Filter, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	expressions := md.Get(eventfilter.FilterMetadataKey)
+	if len(expressions) == 0 || expressions[0] == "" {
+		return nil, nil
+	}
+
+	return eventfilter.Compile(expressions[0])
+}
+
+// projectionFromContext compiles the optional projection a consumer sent along with its registration. Paths can
+// be sent as separate metadata values or comma separated.
+func projectionFromContext(ctx context.Context) (*eventfilter.Projection, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	var paths []string
+	for _, value := range md.Get(eventfilter.ProjectionMetadataKey) {
+		for _, path := range strings.Split(value, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	return eventfilter.CompileProjection(paths)
+}
+
+// structuredPayloadFromContext checks if a consumer registered for structured payloads
+func structuredPayloadFromContext(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	payload := md.Get(structuredevent.PayloadMetadataKey)
+	return len(payload) > 0 && payload[0] == structuredevent.PayloadStructured
+}
+
 func (s *proxyApiServer) Disconnect(ctx context.Context, opts *pb.ProxyApiOptions) (*pb.ProxyApiProviderResponse, error) {
 	proxyApiLogger.Info().
 		Str("consumerAddr", opts.Address).
@@ -177,6 +422,9 @@ func (s *proxyApiServer) Disconnect(ctx context.Context, opts *pb.ProxyApiOption
 
 	proxyApiLogger.Debug().Str("remoteAddr", opts.Address).Msg("Disconnecting using corrected IP address")
 
+	activeProxyConsumersMu.Lock()
+	defer activeProxyConsumersMu.Unlock()
+
 	_, exists := activeProxyConsumers[opts.Address]
 	if !exists {
 		proxyApiLogger.Warn().Str("remoteAddr", opts.Address).
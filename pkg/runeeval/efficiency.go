@@ -0,0 +1,104 @@
+package runeeval
+
+import (
+	"math"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// maxRolls are the highest values a single substat roll of a 6★ rune can have
+var maxRolls = map[gamemodels.EffectType]float64{
+	gamemodels.Hp:     375,
+	gamemodels.HpPct:  8,
+	gamemodels.Atk:    20,
+	gamemodels.AtkPct: 8,
+	gamemodels.Def:    20,
+	gamemodels.DefPct: 8,
+	gamemodels.Spd:    6,
+	gamemodels.Cr:     6,
+	gamemodels.Cd:     7,
+	gamemodels.Res:    8,
+	gamemodels.Acc:    8,
+}
+
+const (
+	// mainStatRolls is the number of max substat rolls the main stat is worth
+	mainStatRolls = 5
+	// maxEfficiencyRolls is the ratio of a perfect 6★ rune: the main stat and 9 max rolls (the innate stat
+	// and 4 substats with 4 additional rolls) relative to the main stat
+	maxEfficiencyRolls = 1 + 9.0/mainStatRolls
+	// expectedRollRatio is the average value of a substat roll relative to its max roll
+	expectedRollRatio = 0.8
+	// upgradeRollLevels are the number of levels between substat rolls, up to +12
+	upgradeRollLevels = 3
+	maxRollLevel      = 12
+)
+
+// Evaluation is the efficiency of a rune in percent relative to a perfect 6★ rune. Grinds count towards the
+// efficiency, enchanted substats count like any other substat.
+type Evaluation struct {
+	RuneId int `json:"rune_id"`
+	// Efficiency is the current efficiency of the rune
+	Efficiency float64 `json:"efficiency"`
+	// GrindEfficiency is the part of Efficiency that is contributed by grinds
+	GrindEfficiency float64 `json:"grind_efficiency"`
+	// RemainingRolls is the number of substat rolls until +12. The rune doesn't gain substat rolls
+	// after +12, so the efficiency at +15 is the same as at +12.
+	RemainingRolls int `json:"remaining_rolls"`
+	// MaxEfficiency is the efficiency at +12 (and +15) if all remaining rolls have their max value
+	MaxEfficiency float64 `json:"max_efficiency"`
+	// ExpectedEfficiency is the efficiency at +12 (and +15) if all remaining rolls have an average value
+	ExpectedEfficiency float64 `json:"expected_efficiency"`
+
+	// Action is the action of the first rule that matched the rune, empty if none matched
+	Action Action `json:"action,omitempty"`
+	// Rule is the rule that matched the rune
+	Rule string `json:"rule,omitempty"`
+}
+
+// Evaluate computes the efficiency of r
+func Evaluate(r gamemodels.Rune) Evaluation {
+	// lower star runes have weaker main stats and rolls, they are measured against 6★ runes
+	starFactor := math.Min(float64(r.Stars), 6) / 6
+
+	ratio, grindRatio := starFactor, 0.0
+	if r.InnateStat != nil {
+		ratio += statRatio(*r.InnateStat, false)
+	}
+	for _, substat := range r.Substats {
+		ratio += statRatio(substat, true)
+		grindRatio += statRatio(substat, true) - statRatio(substat, false)
+	}
+
+	remainingRolls := 0
+	if r.Level < maxRollLevel {
+		remainingRolls = (maxRollLevel - r.Level + upgradeRollLevels - 1) / upgradeRollLevels
+	}
+
+	return Evaluation{
+		RuneId:             r.RuneId,
+		Efficiency:         percent(ratio),
+		GrindEfficiency:    percent(grindRatio),
+		RemainingRolls:     remainingRolls,
+		MaxEfficiency:      percent(ratio + float64(remainingRolls)*starFactor/mainStatRolls),
+		ExpectedEfficiency: percent(ratio + float64(remainingRolls)*starFactor*expectedRollRatio/mainStatRolls),
+	}
+}
+
+// statRatio returns the value of the stat relative to the main stat
+func statRatio(stat gamemodels.RuneStat, withGrind bool) float64 {
+	maxRoll, ok := maxRolls[stat.EffectType]
+	if !ok {
+		return 0
+	}
+
+	value := float64(stat.EffectValue)
+	if withGrind {
+		value += float64(stat.GrindValue)
+	}
+	return value / (maxRoll * mainStatRolls)
+}
+
+func percent(ratio float64) float64 {
+	return math.Round(ratio/maxEfficiencyRolls*10000) / 100
+}
@@ -0,0 +1,73 @@
+package runeeval
+
+import (
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name string
+		r    gamemodels.Rune
+		want Evaluation
+	}{
+		{
+			// the innate stat and 9 max rolls, 5 of them on crit rate
+			name: "perfect rune",
+			r: gamemodels.Rune{RuneId: 1, Stars: 6, Level: 12, Ancient: true,
+				InnateStat: &gamemodels.RuneStat{EffectType: gamemodels.Spd, EffectValue: 6},
+				Substats: []gamemodels.RuneStat{
+					{EffectType: gamemodels.Cr, EffectValue: 30},
+					{EffectType: gamemodels.Cd, EffectValue: 7},
+					{EffectType: gamemodels.HpPct, EffectValue: 8},
+					{EffectType: gamemodels.Acc, EffectValue: 8},
+				}},
+			want: Evaluation{RuneId: 1, Efficiency: 100, MaxEfficiency: 100, ExpectedEfficiency: 100},
+		},
+		{
+			name: "fresh 5 star rune",
+			r: gamemodels.Rune{RuneId: 2, Stars: 5,
+				Substats: []gamemodels.RuneStat{{EffectType: gamemodels.Spd, EffectValue: 4}}},
+			want: Evaluation{RuneId: 2, Efficiency: 34.52, RemainingRolls: 4, MaxEfficiency: 58.33, ExpectedEfficiency: 53.57},
+		},
+		{
+			// the grind counts towards the efficiency, the rune has one roll left at +9
+			name: "grinded rune",
+			r: gamemodels.Rune{RuneId: 3, Stars: 6, Level: 9,
+				Substats: []gamemodels.RuneStat{
+					{EffectType: gamemodels.AtkPct, EffectValue: 5, GrindValue: 3},
+					{EffectType: gamemodels.Hp, EffectValue: 375},
+					{EffectType: gamemodels.Spd, EffectValue: 6, IsEnchanted: true},
+				}},
+			want: Evaluation{RuneId: 3, Efficiency: 57.14, GrindEfficiency: 2.68, RemainingRolls: 1, MaxEfficiency: 64.29, ExpectedEfficiency: 62.86},
+		},
+		{
+			name: "unknown substat",
+			r: gamemodels.Rune{RuneId: 4, Stars: 6, Level: 15,
+				Substats: []gamemodels.RuneStat{{EffectType: gamemodels.EffectType(99), EffectValue: 50}}},
+			want: Evaluation{RuneId: 4, Efficiency: 35.71, MaxEfficiency: 35.71, ExpectedEfficiency: 35.71},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Evaluate(tt.r); got != tt.want {
+				t.Errorf("Evaluate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateRemainingRolls(t *testing.T) {
+	tests := []struct {
+		level int
+		want  int
+	}{
+		{0, 4}, {2, 4}, {3, 3}, {8, 2}, {9, 1}, {11, 1}, {12, 0}, {15, 0},
+	}
+	for _, tt := range tests {
+		if got := Evaluate(gamemodels.Rune{Stars: 6, Level: tt.level}).RemainingRolls; got != tt.want {
+			t.Errorf("remaining rolls at +%d = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package runeeval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// RuneEvaluated is published for every dropped or upgraded rune. The request is the request of the game api
+// event, the response has the fields `source_command`, `rune` (as sent by the game api) and `evaluation`.
+const RuneEvaluated = events.DerivedNamespace + ".RuneEvaluated"
+
+// Evaluator evaluates dropped and upgraded runes and publishes the result as RuneEvaluated.
+//
+// Evaluator is an in-process plugin, see pmanager.Plugin.
+type Evaluator struct {
+	log       zerolog.Logger
+	publisher events.Publisher
+
+	rulesMu sync.RWMutex
+	rules   []Rule
+}
+
+func New(publisher events.Publisher, rules []Rule) *Evaluator {
+	return &Evaluator{
+		log:       log.With().Timestamp().Str("log_type", "module").Str("module", "RuneEvaluator").Logger(),
+		publisher: publisher,
+		rules:     rules,
+	}
+}
+
+// SetRules replaces the keep/sell rules
+func (e *Evaluator) SetRules(rules []Rule) {
+	e.rulesMu.Lock()
+	defer e.rulesMu.Unlock()
+
+	e.rules = rules
+}
+
+func (e *Evaluator) Name() string {
+	return "runeeval"
+}
+
+func (e *Evaluator) Commands() []string {
+	return []string{"BattleDungeonResult_V2", "UpgradeRune", "upgradeRune_v2"}
+}
+
+type runeEvaluatedResponse struct {
+	SourceCommand string          `json:"source_command"`
+	Rune          json.RawMessage `json:"rune"`
+	Evaluation    Evaluation      `json:"evaluation"`
+}
+
+func (e *Evaluator) Handle(_ context.Context, ev events.ApiEventMsg) error {
+	var response struct {
+		gamemodels.ApiResponse
+		Rune            json.RawMessage `json:"rune"`
+		ChangedItemList []struct {
+			Type gamemodels.GameItem `json:"type"`
+			Info json.RawMessage     `json:"info"`
+		} `json:"changed_item_list"`
+	}
+	if err := json.Unmarshal([]byte(ev.Response), &response); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", ev.Command, err)
+	}
+	if response.RetCode != 0 {
+		return nil
+	}
+
+	var runes []json.RawMessage
+	if len(response.Rune) > 0 && string(response.Rune) != "null" {
+		runes = append(runes, response.Rune)
+	}
+	for _, changed := range response.ChangedItemList {
+		if changed.Type == gamemodels.CategoryRune {
+			runes = append(runes, changed.Info)
+		}
+	}
+
+	e.rulesMu.RLock()
+	rules := e.rules
+	e.rulesMu.RUnlock()
+
+	for _, rawRune := range runes {
+		var r gamemodels.Rune
+		if err := json.Unmarshal(rawRune, &r); err != nil {
+			return fmt.Errorf("failed to decode rune of %s: %w", ev.Command, err)
+		}
+
+		evaluation := Decide(rules, r, Evaluate(r))
+		data, err := json.Marshal(runeEvaluatedResponse{SourceCommand: ev.Command, Rune: rawRune, Evaluation: evaluation})
+		if err != nil {
+			return err
+		}
+
+		e.log.Debug().
+			Int("runeId", r.RuneId).
+			Float64("efficiency", evaluation.Efficiency).
+			Float64("maxEfficiency", evaluation.MaxEfficiency).
+			Str("action", string(evaluation.Action)).
+			Msg("Evaluated rune")
+
+		e.publisher.Publish(RuneEvaluated, events.ApiEventMsg{
+			Command:  RuneEvaluated,
+			Request:  ev.Request,
+			Response: string(data),
+			Metadata: ev.Metadata,
+		})
+	}
+
+	return nil
+}
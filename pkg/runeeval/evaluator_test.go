@@ -0,0 +1,58 @@
+package runeeval
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelstest"
+)
+
+func TestEvaluatorHandle(t *testing.T) {
+	var published []events.ApiEventMsg
+	publisher := events.PublisherFunc(func(topic string, msg events.ApiEventMsg) {
+		if topic != RuneEvaluated {
+			t.Errorf("published on %s, want %s", topic, RuneEvaluated)
+		}
+		published = append(published, msg)
+	})
+
+	rule, err := ParseRule("keep:slot=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := New(publisher, []Rule{rule})
+
+	result := gamemodelstest.Event(t, "BattleDungeonResult_V2")
+	// failed commands are ignored
+	gamemodelstest.Handle(t, e, gamemodelstest.Failed(result), result)
+
+	if len(published) != 2 {
+		t.Fatalf("published %d events, want 2", len(published))
+	}
+	tests := []struct {
+		runeId int
+		action Action
+	}{
+		{30000000020, ""},
+		{30000000021, ActionKeep},
+	}
+	for i, tt := range tests {
+		var response struct {
+			SourceCommand string     `json:"source_command"`
+			Evaluation    Evaluation `json:"evaluation"`
+		}
+		if err := json.Unmarshal([]byte(published[i].Response), &response); err != nil {
+			t.Fatal(err)
+		}
+		if response.SourceCommand != "BattleDungeonResult_V2" || response.Evaluation.RuneId != tt.runeId ||
+			response.Evaluation.Action != tt.action {
+			t.Errorf("event %d = %+v, want rune %d with action %q", i, response, tt.runeId, tt.action)
+		}
+	}
+
+	if err := e.Handle(context.Background(), events.ApiEventMsg{Command: "UpgradeRune", Response: `{"ret_code": 0, "rune": {"class": "6"}}`}); err == nil {
+		t.Error("Handle() succeeded for an invalid rune")
+	}
+}
@@ -0,0 +1,185 @@
+package runeeval
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// Action is the decision of a rule about a rune
+type Action string
+
+const (
+	ActionKeep Action = "keep"
+	ActionSell Action = "sell"
+)
+
+// Rule matches runes by their properties and efficiency. All conditions of a rule have to match, empty
+// conditions match all runes.
+type Rule struct {
+	raw string
+
+	Action        Action
+	Sets          []gamemodels.RuneSet
+	Slots         []int
+	MainStats     []gamemodels.EffectType
+	MinStars      int
+	MinQuality    gamemodels.RuneQuality
+	MinEfficiency float64
+	// MinMaxEfficiency is the minimum efficiency the rune can reach at +12, see Evaluation.MaxEfficiency
+	MinMaxEfficiency float64
+}
+
+// ParseRule parses a rule in the form `<keep|sell>[:<condition>;<condition>...]` with the conditions
+// `set=<set>,...`, `slot=<slot>,...`, `main=<stat>,...`, `stars=<min stars>`, `quality=<min quality>`,
+// `efficiency=<min %>` and `max_efficiency=<min %>`, e.g. `keep:set=Violent,Will;slot=2;main=SPD`.
+func ParseRule(s string) (Rule, error) {
+	rule := Rule{raw: s}
+
+	parts := strings.SplitN(s, ":", 2)
+	switch action := Action(strings.ToLower(strings.TrimSpace(parts[0]))); action {
+	case ActionKeep, ActionSell:
+		rule.Action = action
+	default:
+		return rule, fmt.Errorf("unknown action %q", parts[0])
+	}
+	if len(parts) == 1 {
+		return rule, nil
+	}
+
+	for _, condition := range strings.Split(parts[1], ";") {
+		if strings.TrimSpace(condition) == "" {
+			continue
+		}
+		kv := strings.SplitN(condition, "=", 2)
+		if len(kv) != 2 {
+			return rule, fmt.Errorf("condition %q must be in the form <key>=<value>", condition)
+		}
+		key, values := strings.TrimSpace(kv[0]), strings.Split(kv[1], ",")
+
+		var err error
+		switch key {
+		case "set":
+			for _, value := range values {
+				set, ok := parseEnum(value, func(i int) fmt.Stringer { return gamemodels.RuneSet(i) })
+				if !ok {
+					return rule, fmt.Errorf("unknown rune set %q", value)
+				}
+				rule.Sets = append(rule.Sets, gamemodels.RuneSet(set))
+			}
+		case "slot":
+			for _, value := range values {
+				slot, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil || slot < 1 || slot > 6 {
+					return rule, fmt.Errorf("invalid slot %q", value)
+				}
+				rule.Slots = append(rule.Slots, slot)
+			}
+		case "main":
+			for _, value := range values {
+				stat, ok := parseEnum(value, func(i int) fmt.Stringer { return gamemodels.EffectType(i) })
+				if !ok {
+					return rule, fmt.Errorf("unknown stat %q", value)
+				}
+				rule.MainStats = append(rule.MainStats, gamemodels.EffectType(stat))
+			}
+		case "stars":
+			rule.MinStars, err = strconv.Atoi(strings.TrimSpace(kv[1]))
+		case "quality":
+			quality, ok := parseEnum(kv[1], func(i int) fmt.Stringer { return gamemodels.RuneQuality(i) })
+			if !ok {
+				return rule, fmt.Errorf("unknown quality %q", kv[1])
+			}
+			rule.MinQuality = gamemodels.RuneQuality(quality)
+		case "efficiency":
+			rule.MinEfficiency, err = strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		case "max_efficiency":
+			rule.MinMaxEfficiency, err = strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		default:
+			return rule, fmt.Errorf("unknown condition %q", key)
+		}
+		if err != nil {
+			return rule, fmt.Errorf("invalid value of condition %q: %w", key, err)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r Rule) String() string {
+	return r.raw
+}
+
+// Matches checks if the rune and its evaluation fulfill all conditions of the rule
+func (r Rule) Matches(gameRune gamemodels.Rune, evaluation Evaluation) bool {
+	if len(r.Sets) > 0 && !containsSet(r.Sets, gameRune.RuneSet) {
+		return false
+	}
+	if len(r.Slots) > 0 && !containsInt(r.Slots, gameRune.Slot) {
+		return false
+	}
+	if len(r.MainStats) > 0 && !containsStat(r.MainStats, gameRune.MainStat.EffectType) {
+		return false
+	}
+
+	return gameRune.Stars >= r.MinStars &&
+		gameRune.Quality >= r.MinQuality &&
+		evaluation.Efficiency >= r.MinEfficiency &&
+		evaluation.MaxEfficiency >= r.MinMaxEfficiency
+}
+
+// Decide applies the action of the first matching rule to the evaluation
+func Decide(rules []Rule, gameRune gamemodels.Rune, evaluation Evaluation) Evaluation {
+	for _, rule := range rules {
+		if rule.Matches(gameRune, evaluation) {
+			evaluation.Action = rule.Action
+			evaluation.Rule = rule.String()
+			break
+		}
+	}
+	return evaluation
+}
+
+// parseEnum finds the enum value whose name is s, the names of all game enums are short and start at 1. Values
+// without a name are called "Unknown", so it is never a valid name.
+func parseEnum(s string, name func(int) fmt.Stringer) (int, bool) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "Unknown") {
+		return 0, false
+	}
+	for i := 1; i < 100; i++ {
+		if strings.EqualFold(name(i).String(), s) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func containsSet(sets []gamemodels.RuneSet, set gamemodels.RuneSet) bool {
+	for _, s := range sets {
+		if s == set {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStat(stats []gamemodels.EffectType, stat gamemodels.EffectType) bool {
+	for _, s := range stats {
+		if s == stat {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(ints []int, i int) bool {
+	for _, v := range ints {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
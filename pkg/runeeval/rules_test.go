@@ -0,0 +1,72 @@
+package runeeval
+
+import (
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("keep: set=Violent, Will; slot=2,4; main=SPD; stars=6; quality=Hero; max_efficiency=80.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Action != ActionKeep || len(rule.Sets) != 2 || rule.Sets[0] != gamemodels.RuneSet(13) ||
+		len(rule.Slots) != 2 || rule.Slots[1] != 4 || len(rule.MainStats) != 1 || rule.MainStats[0] != gamemodels.Spd ||
+		rule.MinStars != 6 || rule.MinQuality != gamemodels.RuneQuality(4) || rule.MinMaxEfficiency != 80.5 {
+		t.Errorf("rule = %+v", rule)
+	}
+
+	if rule, err := ParseRule("SELL"); err != nil || rule.Action != ActionSell {
+		t.Errorf("ParseRule(SELL) = %+v, %v", rule, err)
+	}
+
+	for _, invalid := range []string{
+		"discard",
+		"keep:set",
+		"keep:set=Unknown",
+		"keep:slot=7",
+		"keep:main=Luck",
+		"keep:stars=six",
+		"keep:quality=Mythic",
+		"keep:efficiency=high",
+		"keep:level=12",
+	} {
+		if _, err := ParseRule(invalid); err == nil {
+			t.Errorf("ParseRule(%s) succeeded", invalid)
+		}
+	}
+}
+
+func TestDecide(t *testing.T) {
+	var rules []Rule
+	for _, s := range []string{"keep:main=SPD;slot=2", "keep:max_efficiency=80", "sell:stars=5", "sell:quality=Common"} {
+		rule, err := ParseRule(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+
+	tests := []struct {
+		name       string
+		r          gamemodels.Rune
+		evaluation Evaluation
+		want       Action
+		wantRule   string
+	}{
+		{"speed slot 2", gamemodels.Rune{Slot: 2, Stars: 5, MainStat: gamemodels.RuneStat{EffectType: gamemodels.Spd}}, Evaluation{}, ActionKeep, "keep:main=SPD;slot=2"},
+		{"efficient rune", gamemodels.Rune{Slot: 4, Stars: 6}, Evaluation{MaxEfficiency: 85}, ActionKeep, "keep:max_efficiency=80"},
+		{"5 star rune", gamemodels.Rune{Slot: 4, Stars: 5}, Evaluation{MaxEfficiency: 60}, ActionSell, "sell:stars=5"},
+		{"4 star rune", gamemodels.Rune{Slot: 4, Stars: 4, Quality: gamemodels.RuneQuality(1)}, Evaluation{}, ActionSell, "sell:quality=Common"},
+		{"no match", gamemodels.Rune{Slot: 4, Stars: 4}, Evaluation{}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Decide(rules, tt.r, tt.evaluation)
+			if got.Action != tt.want || got.Rule != tt.wantRule {
+				t.Errorf("Decide() = %s (%s), want %s (%s)", got.Action, got.Rule, tt.want, tt.wantRule)
+			}
+		})
+	}
+}
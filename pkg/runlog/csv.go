@@ -0,0 +1,188 @@
+package runlog
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var csvHeader = []string{"kind", "dungeon_id", "stage_id", "battle_key", "start", "duration_s", "win",
+	"energy_spent", "mana", "crystal", "runes", "crafts", "essences", "scrolls", "monsters", "other"}
+
+// CSVSink appends runs to a csv file
+type CSVSink struct {
+	mu   sync.Mutex
+	file string
+}
+
+func NewCSVSink(file string) *CSVSink {
+	return &CSVSink{file: file}
+}
+
+func (s *CSVSink) WriteRun(run Run) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		if err := w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	if err := w.Write(runRecord(run)); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func runRecord(run Run) []string {
+	return []string{
+		run.Kind,
+		strconv.Itoa(run.DungeonId),
+		strconv.Itoa(run.StageId),
+		strconv.Itoa(run.BattleKey),
+		run.Start.Format(time.RFC3339),
+		strconv.FormatFloat(run.Duration.Seconds(), 'f', 3, 64),
+		strconv.FormatBool(run.Win),
+		strconv.Itoa(run.EnergySpent),
+		strconv.Itoa(run.Mana),
+		strconv.Itoa(run.Crystal),
+		strconv.Itoa(run.Runes),
+		strconv.Itoa(run.Crafts),
+		strconv.Itoa(run.Essences),
+		strconv.Itoa(run.Scrolls),
+		strconv.Itoa(run.Monsters),
+		strconv.Itoa(run.Other),
+	}
+}
+
+// ReadCSV reads runs that were written by CSVSink
+func ReadCSV(r io.Reader) ([]Run, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+
+	var runs []Run
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return runs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && record[0] == csvHeader[0] {
+			continue
+		}
+
+		run, err := parseRunRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid run in line %d: %w", line, err)
+		}
+		runs = append(runs, run)
+	}
+}
+
+func parseRunRecord(record []string) (Run, error) {
+	run := Run{Kind: record[0]}
+
+	var err error
+	ints := []struct {
+		field *int
+		value string
+	}{
+		{&run.DungeonId, record[1]}, {&run.StageId, record[2]}, {&run.BattleKey, record[3]},
+		{&run.EnergySpent, record[7]}, {&run.Mana, record[8]}, {&run.Crystal, record[9]},
+		{&run.Runes, record[10]}, {&run.Crafts, record[11]}, {&run.Essences, record[12]},
+		{&run.Scrolls, record[13]}, {&run.Monsters, record[14]}, {&run.Other, record[15]},
+	}
+	for _, i := range ints {
+		if *i.field, err = strconv.Atoi(i.value); err != nil {
+			return run, err
+		}
+	}
+
+	if run.Start, err = time.Parse(time.RFC3339, record[4]); err != nil {
+		return run, err
+	}
+	seconds, err := strconv.ParseFloat(record[5], 64)
+	if err != nil {
+		return run, err
+	}
+	run.Duration = time.Duration(seconds * float64(time.Second))
+	if run.Win, err = strconv.ParseBool(record[6]); err != nil {
+		return run, err
+	}
+
+	return run, nil
+}
+
+// StatsFileSink rewrites the aggregated runs of a Logger to a csv file after every run
+type StatsFileSink struct {
+	logger *Logger
+	file   string
+}
+
+func NewStatsFileSink(logger *Logger, file string) *StatsFileSink {
+	return &StatsFileSink{logger: logger, file: file}
+}
+
+func (s *StatsFileSink) WriteRun(Run) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(s.file), ".stats-*.csv")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := s.logger.WriteStatsCSV(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.file)
+}
+
+// LoadCSV aggregates the runs of an earlier session from a file written by CSVSink. A missing file is not an error.
+func (l *Logger) LoadCSV(file string) error {
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	runs, err := ReadCSV(f)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		l.Add(run)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package runlog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "runlog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	runs := []Run{
+		{Kind: KindDungeon, DungeonId: 8001, StageId: 10, BattleKey: 11, Start: start, Duration: 49500 * time.Millisecond,
+			Win: true, EnergySpent: 9, Mana: 1200, Crystal: 3, Runes: 2, Crafts: 1, Essences: 1, Scrolls: 1, Monsters: 1, Other: 1},
+		{Kind: KindTrialTower, StageId: 5, BattleKey: 12, Start: start.Add(time.Minute), Duration: time.Minute},
+	}
+	file := filepath.Join(dir, "runs.csv")
+	sink := NewCSVSink(file)
+	for _, run := range runs {
+		if err := sink.WriteRun(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the runs of an earlier session are aggregated
+	l := New()
+	if err := l.LoadCSV(file); err != nil {
+		t.Fatal(err)
+	}
+	stats := l.Stats()
+	if len(stats) != 2 || stats[0].Runs != 1 || stats[0].Mana != 1200 || stats[0].TotalDuration != 49500*time.Millisecond {
+		t.Errorf("stats = %+v, want the runs of the csv file", stats)
+	}
+
+	if err := l.LoadCSV(filepath.Join(dir, "missing.csv")); err != nil {
+		t.Errorf("LoadCSV() of a missing file = %v", err)
+	}
+
+	statsFile := filepath.Join(dir, "stats.csv")
+	if err := NewStatsFileSink(l, statsFile).WriteRun(Run{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(statsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 3 ||
+		lines[1] != "dungeon,8001,10,1,1,1.000,49.5,9,1200,2,2.000,1,1,1,1,1" {
+		t.Errorf("stats file = %s", data)
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	for _, invalid := range []string{
+		"dungeon,8001,10",
+		"dungeon,8001,ten,11,2020-10-08T12:00:00Z,49.5,true,9,1200,3,2,1,1,1,1,1",
+		"dungeon,8001,10,11,yesterday,49.5,true,9,1200,3,2,1,1,1,1,1",
+		"dungeon,8001,10,11,2020-10-08T12:00:00Z,49.5,won,9,1200,3,2,1,1,1,1,1",
+	} {
+		if _, err := ReadCSV(strings.NewReader(invalid)); err == nil {
+			t.Errorf("ReadCSV(%s) succeeded", invalid)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package runlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// Kinds of runs
+const (
+	KindDungeon    = "dungeon"
+	KindTrialTower = "trial_tower"
+)
+
+// Run is a finished battle in a dungeon or on a floor of the trial tower
+type Run struct {
+	Kind      string `json:"kind"`
+	DungeonId int    `json:"dungeon_id"`
+	// StageId is the stage of the dungeon or the floor of the trial tower
+	StageId   int           `json:"stage_id"`
+	BattleKey int           `json:"battle_key"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration"`
+	Win       bool          `json:"win"`
+	// EnergySpent is the energy the wizard lost by starting the run, 0 if it is unknown
+	EnergySpent int `json:"energy_spent"`
+	Mana        int `json:"mana"`
+	Crystal     int `json:"crystal"`
+
+	// number of rewards by category
+	Runes    int `json:"runes"`
+	Crafts   int `json:"crafts"`
+	Essences int `json:"essences"`
+	Scrolls  int `json:"scrolls"`
+	Monsters int `json:"monsters"`
+	Other    int `json:"other"`
+}
+
+// Logger pairs the start and result of dungeon and trial tower battles into runs. Finished runs are passed to
+// all sinks (see CSVSink) and aggregated per dungeon and stage.
+//
+// Logger is an in-process plugin, see pmanager.Plugin.
+type Logger struct {
+	log   zerolog.Logger
+	sinks []Sink
+
+	// energy is the last known energy of the wizard, -1 if it is unknown
+	energy int
+	// pending runs by their kind, there can only be one battle at a time
+	pending map[string]Run
+
+	mu    sync.RWMutex
+	stats map[StatsKey]*Stats
+}
+
+// Sink receives finished runs
+type Sink interface {
+	WriteRun(run Run) error
+}
+
+func New(sinks ...Sink) *Logger {
+	return &Logger{
+		log:     log.With().Timestamp().Str("log_type", "module").Str("module", "RunLogger").Logger(),
+		sinks:   sinks,
+		energy:  -1,
+		pending: map[string]Run{},
+		stats:   map[StatsKey]*Stats{},
+	}
+}
+
+// AddSink adds a sink for finished runs, sinks have to be added before the logger is registered as plugin
+func (l *Logger) AddSink(sink Sink) {
+	l.sinks = append(l.sinks, sink)
+}
+
+func (l *Logger) Name() string {
+	return "runlog"
+}
+
+// Commands subscribes to all game commands, the energy of the wizard is tracked from all responses
+func (l *Logger) Commands() []string {
+	return []string{"*"}
+}
+
+type battleRequest struct {
+	DungeonId int `json:"dungeon_id"`
+	StageId   int `json:"stage_id"`
+	FloorId   int `json:"floor_id"`
+	BattleKey int `json:"battle_key"`
+}
+
+type battleResponse struct {
+	gamemodels.ApiResponse
+	WizardInfo      *gamemodels.WizardInfo                   `json:"wizard_info"`
+	BattleKey       int                                      `json:"battle_key"`
+	WinLose         gamemodels.BattleOutcome                 `json:"win_lose"`
+	Reward          gamemodels.DungeonReward                 `json:"reward"`
+	ChangedItemList []gamemodels.DungeonChangedItemListEntry `json:"changed_item_list"`
+}
+
+func (l *Logger) Handle(_ context.Context, ev events.ApiEventMsg) error {
+	if events.Namespace(ev.Command) != "" {
+		return nil
+	}
+
+	var response battleResponse
+	if err := json.Unmarshal([]byte(ev.Response), &response); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", ev.Command, err)
+	}
+	if response.RetCode != 0 {
+		return nil
+	}
+
+	energyBefore := l.energy
+	if response.WizardInfo != nil {
+		l.energy = response.WizardInfo.WizardEnergy
+	}
+
+	switch ev.Command {
+	case "BattleDungeonStart", "BattleTrialTowerStart_v2":
+		var request battleRequest
+		if err := json.Unmarshal([]byte(ev.Request), &request); err != nil {
+			return fmt.Errorf("failed to decode request of %s: %w", ev.Command, err)
+		}
+
+		run := Run{Kind: KindDungeon, DungeonId: request.DungeonId, StageId: request.StageId,
+			BattleKey: response.BattleKey, Start: eventTime(ev)}
+		if ev.Command == "BattleTrialTowerStart_v2" {
+			run.Kind, run.StageId = KindTrialTower, request.FloorId
+		}
+		if energyBefore >= 0 && response.WizardInfo != nil && energyBefore > l.energy {
+			run.EnergySpent = energyBefore - l.energy
+		}
+		l.pending[run.Kind] = run
+
+	case "BattleDungeonResult_V2", "BattleTrialTowerResult_v2", "BattleTrialTowerResult_V2":
+		kind := KindDungeon
+		if ev.Command != "BattleDungeonResult_V2" {
+			kind = KindTrialTower
+		}
+
+		var request battleRequest
+		if err := json.Unmarshal([]byte(ev.Request), &request); err != nil {
+			return fmt.Errorf("failed to decode request of %s: %w", ev.Command, err)
+		}
+
+		pending, ok := l.pending[kind]
+		if !ok || (request.BattleKey != 0 && pending.BattleKey != 0 && request.BattleKey != pending.BattleKey) {
+			l.log.Warn().Str("command", ev.Command).Int("battleKey", request.BattleKey).
+				Msg("Received battle result without a matching start")
+			return nil
+		}
+		delete(l.pending, kind)
+
+		l.finish(pending, ev, response)
+	}
+
+	return nil
+}
+
+func (l *Logger) finish(run Run, ev events.ApiEventMsg, response battleResponse) {
+	run.Duration = eventTime(ev).Sub(run.Start)
+	run.Win = response.WinLose == gamemodels.BattleWin
+	run.Mana = response.Reward.Mana
+	run.Crystal = response.Reward.Crystal
+
+	for _, changed := range response.ChangedItemList {
+		switch changed.Type {
+		case gamemodels.CategoryRune:
+			run.Runes++
+		case gamemodels.CategoryRuneCraft, gamemodels.CategoryCraftStuff:
+			run.Crafts++
+		case gamemodels.CategoryEssence:
+			run.Essences++
+		case gamemodels.CategorySummonScroll:
+			run.Scrolls++
+		case gamemodels.CategoryMonster:
+			run.Monsters++
+		default:
+			run.Other++
+		}
+	}
+
+	l.Add(run)
+
+	for _, sink := range l.sinks {
+		if err := sink.WriteRun(run); err != nil {
+			l.log.Error().Err(err).Msg("Failed to write run")
+		}
+	}
+
+	l.log.Info().
+		Str("kind", run.Kind).
+		Int("dungeonId", run.DungeonId).
+		Int("stageId", run.StageId).
+		Bool("win", run.Win).
+		Dur("duration", run.Duration).
+		Int("runes", run.Runes).
+		Msg("Finished run")
+}
+
+// eventTime returns the time the game client sent the request
+func eventTime(ev events.ApiEventMsg) time.Time {
+	if !ev.Metadata.RequestStart.IsZero() {
+		return ev.Metadata.RequestStart
+	}
+	return time.Now()
+}
@@ -0,0 +1,106 @@
+package runlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelstest"
+)
+
+type runSink []Run
+
+func (s *runSink) WriteRun(run Run) error {
+	*s = append(*s, run)
+	return nil
+}
+
+var start = time.Date(2020, 10, 8, 12, 0, 0, 0, time.UTC)
+
+// recorded returns the recorded exchange name, sent at start plus at
+func recorded(t *testing.T, name string, at time.Duration) events.ApiEventMsg {
+	t.Helper()
+
+	ev := gamemodelstest.Event(t, name)
+	ev.Metadata.RequestStart = start.Add(at)
+	return ev
+}
+
+func TestLoggerPairsRuns(t *testing.T) {
+	sink := &runSink{}
+	l := New(sink)
+
+	gamemodelstest.Handle(t, l,
+		recorded(t, "GetWizardInfo", 0),
+		recorded(t, "BattleDungeonStart", time.Second),
+		// the trial tower runs independently of the dungeon
+		recorded(t, "BattleTrialTowerStart_v2", 2*time.Second),
+		recorded(t, "BattleDungeonResult_V2", 50*time.Second),
+		recorded(t, "BattleTrialTowerResult_v2", 62*time.Second),
+	)
+
+	want := []Run{
+		{Kind: KindDungeon, DungeonId: 8001, StageId: 10, BattleKey: 1234567, Start: start.Add(time.Second),
+			Duration: 49 * time.Second, Win: true, EnergySpent: 9, Mana: 1200, Crystal: 3,
+			Runes: 2, Crafts: 1, Essences: 1, Scrolls: 1, Monsters: 1, Other: 1},
+		{Kind: KindTrialTower, StageId: 5, BattleKey: 1234568, Start: start.Add(2 * time.Second), Duration: time.Minute},
+	}
+	if len(*sink) != len(want) {
+		t.Fatalf("logged %d runs, want %d", len(*sink), len(want))
+	}
+	for i, run := range *sink {
+		if run != want[i] {
+			t.Errorf("run %d = %+v, want %+v", i, run, want[i])
+		}
+	}
+}
+
+func TestLoggerUnpairedResults(t *testing.T) {
+	sink := &runSink{}
+	l := New(sink)
+
+	result := recorded(t, "BattleDungeonResult_V2", 4*time.Second)
+	gamemodelstest.Handle(t, l,
+		// a result without a start, e.g. after a restart of the proxy
+		recorded(t, "BattleDungeonResult_V2.lose", 0),
+		recorded(t, "BattleDungeonStart", time.Second),
+		// the result of another battle
+		recorded(t, "BattleDungeonResult_V2.lose", 2*time.Second),
+		// a failed result doesn't finish the run
+		gamemodelstest.Failed(recorded(t, "BattleDungeonResult_V2", 3*time.Second)),
+	)
+	if len(*sink) != 0 {
+		t.Fatalf("logged %+v without a matching start", *sink)
+	}
+
+	// the run was finished, a repeated result is not logged again
+	gamemodelstest.Handle(t, l, result, result)
+	if len(*sink) != 1 || (*sink)[0].BattleKey != 1234567 || (*sink)[0].Duration != 3*time.Second {
+		t.Errorf("logged %+v, want the run with battle key 1234567", *sink)
+	}
+}
+
+func TestLoggerStats(t *testing.T) {
+	l := New()
+	for _, run := range []Run{
+		{Kind: KindTrialTower, StageId: 5, Duration: 30 * time.Second},
+		{Kind: KindDungeon, DungeonId: 8001, StageId: 10, Duration: 40 * time.Second, Win: true, Runes: 2},
+		{Kind: KindDungeon, DungeonId: 8001, StageId: 10, Duration: 60 * time.Second, Win: true, Runes: 1},
+		{Kind: KindDungeon, DungeonId: 8001, StageId: 10, Duration: 20 * time.Second},
+	} {
+		l.Add(run)
+	}
+
+	stats := l.Stats()
+	if len(stats) != 2 || stats[0].Kind != KindDungeon || stats[1].Kind != KindTrialTower {
+		t.Fatalf("stats = %+v, want the dungeon before the trial tower", stats)
+	}
+	dungeon := stats[0]
+	if dungeon.Runs != 3 || dungeon.WinRate() != 2.0/3 || dungeon.AverageDuration() != 40*time.Second ||
+		dungeon.RuneDropRate() != 1.5 {
+		t.Errorf("dungeon stats = %+v", dungeon)
+	}
+	if tower := stats[1]; tower.WinRate() != 0 || tower.RuneDropRate() != 0 {
+		t.Errorf("trial tower stats = %+v", tower)
+	}
+}
@@ -0,0 +1,141 @@
+package runlog
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// StatsKey identifies a dungeon stage or a trial tower floor
+type StatsKey struct {
+	Kind      string `json:"kind"`
+	DungeonId int    `json:"dungeon_id"`
+	StageId   int    `json:"stage_id"`
+}
+
+// Stats aggregates all runs of a dungeon stage or trial tower floor
+type Stats struct {
+	StatsKey
+	Runs          int           `json:"runs"`
+	Wins          int           `json:"wins"`
+	TotalDuration time.Duration `json:"total_duration"`
+	EnergySpent   int           `json:"energy_spent"`
+	Mana          int           `json:"mana"`
+	Runes         int           `json:"runes"`
+	Crafts        int           `json:"crafts"`
+	Essences      int           `json:"essences"`
+	Scrolls       int           `json:"scrolls"`
+	Monsters      int           `json:"monsters"`
+	Other         int           `json:"other"`
+}
+
+// WinRate returns the share of won runs
+func (s Stats) WinRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Runs)
+}
+
+// AverageDuration returns the average duration of a run
+func (s Stats) AverageDuration() time.Duration {
+	if s.Runs == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Runs)
+}
+
+// RuneDropRate returns the average number of runes per won run
+func (s Stats) RuneDropRate() float64 {
+	if s.Wins == 0 {
+		return 0
+	}
+	return float64(s.Runes) / float64(s.Wins)
+}
+
+// Add aggregates run, e.g. to include runs of earlier sessions read with ReadCSV
+func (l *Logger) Add(run Run) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := StatsKey{Kind: run.Kind, DungeonId: run.DungeonId, StageId: run.StageId}
+	stats, ok := l.stats[key]
+	if !ok {
+		stats = &Stats{StatsKey: key}
+		l.stats[key] = stats
+	}
+
+	stats.Runs++
+	if run.Win {
+		stats.Wins++
+	}
+	stats.TotalDuration += run.Duration
+	stats.EnergySpent += run.EnergySpent
+	stats.Mana += run.Mana
+	stats.Runes += run.Runes
+	stats.Crafts += run.Crafts
+	stats.Essences += run.Essences
+	stats.Scrolls += run.Scrolls
+	stats.Monsters += run.Monsters
+	stats.Other += run.Other
+}
+
+// Stats returns the aggregated runs per dungeon stage and trial tower floor
+func (l *Logger) Stats() []Stats {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	stats := make([]Stats, 0, len(l.stats))
+	for _, s := range l.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i].StatsKey, stats[j].StatsKey
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.DungeonId != b.DungeonId {
+			return a.DungeonId < b.DungeonId
+		}
+		return a.StageId < b.StageId
+	})
+	return stats
+}
+
+// WriteStatsCSV writes the aggregated runs as csv
+func (l *Logger) WriteStatsCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"kind", "dungeon_id", "stage_id", "runs", "wins", "win_rate", "avg_duration_s",
+		"energy_spent", "mana", "runes", "rune_drop_rate", "crafts", "essences", "scrolls", "monsters", "other"}); err != nil {
+		return err
+	}
+
+	for _, s := range l.Stats() {
+		record := []string{
+			s.Kind,
+			strconv.Itoa(s.DungeonId),
+			strconv.Itoa(s.StageId),
+			strconv.Itoa(s.Runs),
+			strconv.Itoa(s.Wins),
+			strconv.FormatFloat(s.WinRate(), 'f', 3, 64),
+			strconv.FormatFloat(s.AverageDuration().Seconds(), 'f', 1, 64),
+			strconv.Itoa(s.EnergySpent),
+			strconv.Itoa(s.Mana),
+			strconv.Itoa(s.Runes),
+			strconv.FormatFloat(s.RuneDropRate(), 'f', 3, 64),
+			strconv.Itoa(s.Crafts),
+			strconv.Itoa(s.Essences),
+			strconv.Itoa(s.Scrolls),
+			strconv.Itoa(s.Monsters),
+			strconv.Itoa(s.Other),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
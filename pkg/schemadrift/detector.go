@@ -0,0 +1,227 @@
+package schemadrift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+// SchemaDrift is published for every Drift, with the Drift as response
+const SchemaDrift = events.DerivedNamespace + ".SchemaDrift"
+
+// recentDrifts is the number of drifts that are kept for Report
+const recentDrifts = 100
+
+// defaultSaveInterval is the SaveInterval of configurations without one
+const defaultSaveInterval = time.Minute
+
+// SchemaStore persists the learned schemas, so they don't have to be learned again after a restart
+type SchemaStore interface {
+	LoadSchemas() ([]*Schema, error)
+	SaveSchema(schema *Schema) error
+}
+
+// Configuration of a Detector
+type Configuration struct {
+	// MinObservations is the number of responses of a command that are learned before drift is reported
+	MinObservations int
+	// Schemas is optional
+	Schemas SchemaStore
+	// SaveInterval is the minimum time between saving schemas that only counted responses. Schemas that learned
+	// something are saved immediately, all unsaved schemas are saved on shutdown.
+	SaveInterval time.Duration
+}
+
+// Metrics describe the learned schemas and the detected drift
+type Metrics struct {
+	Responses     uint64    `json:"responses"`
+	Commands      int       `json:"commands"`
+	NewFields     uint64    `json:"new_fields"`
+	MissingFields uint64    `json:"missing_fields"`
+	TypeChanges   uint64    `json:"type_changes"`
+	LastDrift     time.Time `json:"last_drift"`
+}
+
+// Report contains the metrics and the most recent drifts of a Detector
+type Report struct {
+	Metrics Metrics `json:"metrics"`
+	Drifts  []Drift `json:"drifts"`
+}
+
+// Detector learns the schema of the responses of every game command and reports new, missing and
+// type-changed fields as warnings and SchemaDrift events, so game updates that break plugins are noticed early.
+//
+// Detector is an in-process plugin, see pmanager.Plugin.
+type Detector struct {
+	log           zerolog.Logger
+	publisher     events.Publisher
+	configuration Configuration
+
+	// schemas by command, they are only used by the plugin goroutine
+	schemas map[string]*Schema
+	// unsaved are the commands of schemas that counted responses since they were saved
+	unsaved  map[string]bool
+	lastSave time.Time
+
+	mu      sync.RWMutex
+	metrics Metrics
+	drifts  []Drift
+}
+
+// New creates a detector and loads the schemas learned earlier from the schema store
+func New(publisher events.Publisher, configuration Configuration) (*Detector, error) {
+	d := &Detector{
+		log:           log.With().Timestamp().Str("log_type", "module").Str("module", "SchemaDrift").Logger(),
+		publisher:     publisher,
+		configuration: configuration,
+		schemas:       map[string]*Schema{},
+		unsaved:       map[string]bool{},
+		lastSave:      time.Now(),
+	}
+	if d.configuration.SaveInterval <= 0 {
+		d.configuration.SaveInterval = defaultSaveInterval
+	}
+
+	if configuration.Schemas != nil {
+		schemas, err := configuration.Schemas.LoadSchemas()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load schemas: %w", err)
+		}
+		for _, schema := range schemas {
+			d.schemas[schema.Command] = schema
+		}
+		d.metrics.Commands = len(d.schemas)
+	}
+
+	return d, nil
+}
+
+func (d *Detector) Name() string {
+	return "schemadrift"
+}
+
+func (d *Detector) Commands() []string {
+	return []string{"*"}
+}
+
+// Report returns the metrics and the most recent drifts, the oldest first
+func (d *Detector) Report() Report {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return Report{Metrics: d.metrics, Drifts: append([]Drift(nil), d.drifts...)}
+}
+
+func (d *Detector) Handle(_ context.Context, ev events.ApiEventMsg) error {
+	if ev.Command == events.ProxyShuttingDown {
+		d.saveUnsaved()
+		return nil
+	}
+	if events.Namespace(ev.Command) != "" {
+		return nil
+	}
+
+	var response interface{}
+	if err := json.Unmarshal([]byte(ev.Response), &response); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", ev.Command, err)
+	}
+	// failed requests have a different schema
+	if object, ok := response.(map[string]interface{}); ok {
+		if retCode, ok := object["ret_code"].(float64); ok && retCode != 0 {
+			return nil
+		}
+	}
+
+	schema, ok := d.schemas[ev.Command]
+	if !ok {
+		schema = NewSchema(ev.Command)
+		d.schemas[ev.Command] = schema
+	}
+
+	now := time.Now()
+	drifts, changed := schema.Observe(response, d.configuration.MinObservations, now)
+	d.record(len(d.schemas), drifts)
+
+	d.unsaved[ev.Command] = true
+	if changed {
+		d.save(schema)
+	}
+	if now.Sub(d.lastSave) >= d.configuration.SaveInterval {
+		d.saveUnsaved()
+	}
+
+	for _, drift := range drifts {
+		d.log.Warn().
+			Str("command", drift.Command).
+			Str("path", drift.Path).
+			Str("kind", drift.Kind).
+			Strs("expected", drift.Expected).
+			Strs("observed", drift.Observed).
+			Msg("Game api response differs from the learned schema")
+
+		data, err := json.Marshal(drift)
+		if err != nil {
+			d.log.Error().Err(err).Msg("Failed to encode schema drift")
+			continue
+		}
+		d.publisher.Publish(SchemaDrift, events.ApiEventMsg{
+			Command:  SchemaDrift,
+			Request:  ev.Request,
+			Response: string(data),
+			Metadata: ev.Metadata,
+		})
+	}
+
+	return nil
+}
+
+// saveUnsaved saves all schemas that counted responses since they were saved
+func (d *Detector) saveUnsaved() {
+	for command := range d.unsaved {
+		d.save(d.schemas[command])
+	}
+	d.lastSave = time.Now()
+}
+
+func (d *Detector) save(schema *Schema) {
+	if d.configuration.Schemas == nil {
+		return
+	}
+
+	if err := d.configuration.Schemas.SaveSchema(schema); err != nil {
+		d.log.Error().Err(err).Str("command", schema.Command).Msg("Failed to save schema")
+		return
+	}
+	delete(d.unsaved, schema.Command)
+}
+
+func (d *Detector) record(commands int, drifts []Drift) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.metrics.Responses++
+	d.metrics.Commands = commands
+	for _, drift := range drifts {
+		switch drift.Kind {
+		case KindNewField:
+			d.metrics.NewFields++
+		case KindMissingField:
+			d.metrics.MissingFields++
+		case KindTypeChanged:
+			d.metrics.TypeChanges++
+		}
+		d.metrics.LastDrift = drift.DetectedAt
+	}
+
+	d.drifts = append(d.drifts, drifts...)
+	if len(d.drifts) > recentDrifts {
+		d.drifts = append([]Drift(nil), d.drifts[len(d.drifts)-recentDrifts:]...)
+	}
+}
@@ -0,0 +1,76 @@
+package schemadrift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+// memorySchemas keeps copies of the saved schemas
+type memorySchemas map[string]Schema
+
+func (m memorySchemas) LoadSchemas() ([]*Schema, error) {
+	var schemas []*Schema
+	for _, schema := range m {
+		schema := schema
+		schemas = append(schemas, &schema)
+	}
+	return schemas, nil
+}
+
+func (m memorySchemas) SaveSchema(schema *Schema) error {
+	m[schema.Command] = *schema
+	return nil
+}
+
+func TestDetectorSavesSchemas(t *testing.T) {
+	var published []events.ApiEventMsg
+	publisher := events.PublisherFunc(func(topic string, msg events.ApiEventMsg) { published = append(published, msg) })
+	handle := func(d *Detector, command, response string) {
+		t.Helper()
+		if err := d.Handle(context.Background(), events.ApiEventMsg{Command: command, Request: `{}`, Response: response}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	schemas := memorySchemas{}
+	d, err := New(publisher, Configuration{MinObservations: 1, Schemas: schemas, SaveInterval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the new schema is saved immediately, the response count of the unchanged schema only on shutdown
+	handle(d, "GetWizardInfo", `{"ret_code": 0, "wizard_info": {"wizard_id": 1}}`)
+	handle(d, "GetWizardInfo", `{"ret_code": 0, "wizard_info": {"wizard_id": 1}}`)
+	// failed requests are not learned
+	handle(d, "GetWizardInfo", `{"ret_code": 1}`)
+	if responses := schemas["GetWizardInfo"].Responses; responses != 1 {
+		t.Errorf("saved schema counted %d responses before the shutdown, want 1", responses)
+	}
+	handle(d, events.ProxyShuttingDown, `{}`)
+	if responses := schemas["GetWizardInfo"].Responses; responses != 2 {
+		t.Errorf("saved schema counted %d responses after the shutdown, want 2", responses)
+	}
+
+	// the learned schema is loaded after a restart and drift is reported right away
+	d, err = New(publisher, Configuration{MinObservations: 1, Schemas: schemas, SaveInterval: time.Nanosecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handle(d, "GetWizardInfo", `{"ret_code": 0, "wizard_info": {"wizard_id": "1"}}`)
+	handle(d, "GetWizardInfo", `{"ret_code": 0, "wizard_info": {"wizard_id": 1}}`)
+	if len(published) != 1 || published[0].Command != SchemaDrift {
+		t.Fatalf("published %+v, want a single drift", published)
+	}
+	// unchanged schemas are saved once the save interval passed
+	if responses := schemas["GetWizardInfo"].Responses; responses != 4 {
+		t.Errorf("saved schema counted %d responses, want 4", responses)
+	}
+
+	report := d.Report()
+	if report.Metrics.Responses != 2 || report.Metrics.Commands != 1 || report.Metrics.TypeChanges != 1 || len(report.Drifts) != 1 {
+		t.Errorf("report = %+v", report)
+	}
+}
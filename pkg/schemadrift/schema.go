@@ -0,0 +1,236 @@
+package schemadrift
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSON types of a field
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeNull    = "null"
+)
+
+// Kinds of drift
+const (
+	// KindNewField is a field that was never part of a response before
+	KindNewField = "new_field"
+	// KindMissingField is a field that was part of every response before, unless its parent was missing
+	KindMissingField = "missing_field"
+	// KindTypeChanged is a field with a type it never had before
+	KindTypeChanged = "type_changed"
+)
+
+// Drift is a difference between a response and the learned schema of its command
+type Drift struct {
+	Command string `json:"command"`
+	// Path of the field, see Schema
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+	// Expected are the known types of the field, empty for new fields
+	Expected []string `json:"expected"`
+	// Observed are the types of the field in the response, empty for missing fields
+	Observed   []string  `json:"observed"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Field of a schema
+type Field struct {
+	// Types are all types the field had, sorted
+	Types []string `json:"types"`
+	// Seen is the number of responses that contained the field
+	Seen int `json:"seen"`
+	// ParentSeen is the number of responses that contained the parent of the field since the field was first seen
+	ParentSeen int `json:"parent_seen"`
+}
+
+func (f *Field) hasType(t string) bool {
+	for _, known := range f.Types {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Field) addType(t string) {
+	f.Types = append(f.Types, t)
+	sort.Strings(f.Types)
+}
+
+// Schema is the learned structure of the responses of a command. Fields are identified by their path, e.g.
+// `unit_list[].runes{}.rune_id`: object keys are separated by dots, `[]` are the elements of an array and `{}`
+// the values of an object that is keyed by numbers (like the runes of a unit, keyed by slot). The root of the
+// response is the empty path.
+type Schema struct {
+	Command   string            `json:"command"`
+	Responses int               `json:"responses"`
+	Fields    map[string]*Field `json:"fields"`
+}
+
+func NewSchema(command string) *Schema {
+	return &Schema{Command: command, Fields: map[string]*Field{}}
+}
+
+// Observe learns the fields of a decoded json response and returns how it differs from the schema. Drift is
+// only reported after minObservations responses, and fields are only reported as missing after their parent
+// was seen minObservations times. Changed is true if the schema learned a new field or type, or a field is
+// no longer part of every response.
+func (s *Schema) Observe(response interface{}, minObservations int, now time.Time) (drifts []Drift, changed bool) {
+	observed := map[string]map[string]bool{}
+	flatten(response, "", observed)
+
+	learned := s.Responses >= minObservations
+	s.Responses++
+	report := func(path, kind string, expected, observedTypes []string) {
+		drifts = append(drifts, Drift{Command: s.Command, Path: path, Kind: kind, Expected: expected,
+			Observed: observedTypes, DetectedAt: now})
+	}
+
+	paths := make([]string, 0, len(observed))
+	for path := range observed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		types := observed[path]
+
+		field, ok := s.Fields[path]
+		if !ok {
+			field = &Field{}
+			s.Fields[path] = field
+			changed = true
+			if learned && path != "" {
+				report(path, KindNewField, nil, typeList(types))
+			}
+		}
+
+		for _, t := range typeList(types) {
+			if field.hasType(t) {
+				continue
+			}
+			// a field that was always null gets its actual type, which isn't a change
+			if ok && learned && t != TypeNull && !(len(field.Types) == 1 && field.Types[0] == TypeNull) {
+				report(path, KindTypeChanged, append([]string(nil), field.Types...), typeList(types))
+			}
+			field.addType(t)
+			changed = true
+		}
+		field.Seen++
+	}
+
+	paths = paths[:0]
+	for path := range s.Fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		field := s.Fields[path]
+		parent, keyed := parentPath(path)
+		if path == "" || !observed[parent][TypeObject] && !observed[parent][TypeArray] {
+			continue
+		}
+
+		// elements of arrays and maps are optional, arrays may be empty
+		if _, ok := observed[path]; !ok && keyed {
+			if field.Seen == field.ParentSeen {
+				changed = true
+				if learned && field.ParentSeen >= minObservations {
+					report(path, KindMissingField, append([]string(nil), field.Types...), nil)
+				}
+			}
+		}
+		field.ParentSeen++
+	}
+
+	return drifts, changed
+}
+
+// flatten adds the types of value and all its nested fields to fields
+func flatten(value interface{}, path string, fields map[string]map[string]bool) {
+	if fields[path] == nil {
+		fields[path] = map[string]bool{}
+	}
+	fields[path][typeOf(value)] = true
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if isNumberKeyed(v) {
+			for _, element := range v {
+				flatten(element, path+"{}", fields)
+			}
+			return
+		}
+		for key, element := range v {
+			flatten(element, joinPath(path, key), fields)
+		}
+	case []interface{}:
+		for _, element := range v {
+			flatten(element, path+"[]", fields)
+		}
+	}
+}
+
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return TypeObject
+	case []interface{}:
+		return TypeArray
+	case string:
+		return TypeString
+	case bool:
+		return TypeBoolean
+	case nil:
+		return TypeNull
+	default:
+		return TypeNumber
+	}
+}
+
+// isNumberKeyed returns true for non-empty objects whose keys are all numbers
+func isNumberKeyed(object map[string]interface{}) bool {
+	if len(object) == 0 {
+		return false
+	}
+	for key := range object {
+		if _, err := strconv.Atoi(key); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// parentPath returns the path of the parent of a field, and whether the field is a key of its parent (and not
+// an element of an array or map)
+func parentPath(path string) (string, bool) {
+	if strings.HasSuffix(path, "[]") || strings.HasSuffix(path, "{}") {
+		return path[:len(path)-2], false
+	}
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i], true
+	}
+	return "", true
+}
+
+func typeList(types map[string]bool) []string {
+	list := make([]string, 0, len(types))
+	for t := range types {
+		list = append(list, t)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,80 @@
+package schemadrift
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2020, 10, 8, 12, 0, 0, 0, time.UTC)
+
+func observe(t *testing.T, s *Schema, response string) ([]Drift, bool) {
+	t.Helper()
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(response), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return s.Observe(decoded, 2, now)
+}
+
+func TestSchemaObserve(t *testing.T) {
+	s := NewSchema("HubUserLogin")
+	learn := `{"mana": 1, "name": "tester", "event": null, "unit_list": [{"unit_id": 1, "runes": {"1": {"rune_id": 2}}}]}`
+
+	if drifts, changed := observe(t, s, learn); len(drifts) != 0 || !changed {
+		t.Errorf("first response = %v, %v, want a changed schema without drift", drifts, changed)
+	}
+	// a response without units learns nothing
+	if drifts, changed := observe(t, s, `{"mana": 2, "name": "tester", "event": null, "unit_list": []}`); len(drifts) != 0 || changed {
+		t.Errorf("second response = %v, %v, want no changes", drifts, changed)
+	}
+	for _, path := range []string{"", "mana", "unit_list", "unit_list[]", "unit_list[].unit_id", "unit_list[].runes{}.rune_id"} {
+		if _, ok := s.Fields[path]; !ok {
+			t.Errorf("schema has no field %q", path)
+		}
+	}
+
+	drifts, changed := observe(t, s, `{"mana": "3", "event": {"id": 1}, "crystal": 5, "unit_list": [{"unit_id": 1, "runes": {}}]}`)
+	if !changed {
+		t.Error("schema didn't change")
+	}
+	want := []Drift{
+		{Command: "HubUserLogin", Path: "crystal", Kind: KindNewField, Observed: []string{TypeNumber}, DetectedAt: now},
+		// event was always null, its fields are new but it didn't change its type
+		{Command: "HubUserLogin", Path: "event.id", Kind: KindNewField, Observed: []string{TypeNumber}, DetectedAt: now},
+		{Command: "HubUserLogin", Path: "mana", Kind: KindTypeChanged, Expected: []string{TypeNumber}, Observed: []string{TypeString}, DetectedAt: now},
+		{Command: "HubUserLogin", Path: "name", Kind: KindMissingField, Expected: []string{TypeString}, DetectedAt: now},
+	}
+	if !reflect.DeepEqual(drifts, want) {
+		t.Errorf("drifts = %+v, want %+v", drifts, want)
+	}
+	if types := s.Fields["mana"].Types; !reflect.DeepEqual(types, []string{TypeNumber, TypeString}) {
+		t.Errorf("types of mana = %v, want number and string", types)
+	}
+
+	// known drift is only reported once
+	if drifts, _ := observe(t, s, `{"mana": "4", "event": {"id": 1}, "crystal": 5, "unit_list": []}`); len(drifts) != 0 {
+		t.Errorf("repeated drift = %+v, want none", drifts)
+	}
+	if s.Responses != 4 {
+		t.Errorf("schema counted %d responses, want 4", s.Responses)
+	}
+}
+
+func TestSchemaObserveMissingNestedField(t *testing.T) {
+	s := NewSchema("GetWizardInfo")
+	for i := 0; i < 2; i++ {
+		observe(t, s, `{"wizard_info": {"wizard_id": 1, "wizard_mana": 1}}`)
+	}
+
+	// the parent of a field has to be seen for the field to be missing
+	if drifts, _ := observe(t, s, `{}`); len(drifts) != 1 || drifts[0].Path != "wizard_info" {
+		t.Errorf("drifts = %+v, want only wizard_info missing", drifts)
+	}
+	drifts, _ := observe(t, s, `{"wizard_info": {"wizard_id": 1}}`)
+	if len(drifts) != 1 || drifts[0].Path != "wizard_info.wizard_mana" || drifts[0].Kind != KindMissingField {
+		t.Errorf("drifts = %+v, want wizard_info.wizard_mana missing", drifts)
+	}
+}
@@ -0,0 +1,99 @@
+package schemadrift
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/swarpf/proxy/pkg/structuredevent"
+)
+
+// The schema drift service is served next to the proxy api, so dashboards can show the detected drift:
+//
+//	service SchemaDrift {
+//	  rpc GetSchemaDrift(google.protobuf.Empty) returns (google.protobuf.Struct);
+//	}
+//
+// The struct is the json encoding of Report.
+const (
+	ServiceName = "swarpf.proxyapi.SchemaDrift"
+	MethodName  = "GetSchemaDrift"
+	FullMethod  = "/" + ServiceName + "/" + MethodName
+)
+
+// Server is implemented by Detector
+type Server interface {
+	GetSchemaDrift(ctx context.Context, in *emptypb.Empty) (*structpb.Struct, error)
+}
+
+// RegisterServer registers the schema drift service at s
+func RegisterServer(s *grpc.Server, srv Server) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: MethodName,
+			Handler:    handleGetSchemaDrift,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func handleGetSchemaDrift(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Server).GetSchemaDrift(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FullMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Server).GetSchemaDrift(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func (d *Detector) GetSchemaDrift(context.Context, *emptypb.Empty) (*structpb.Struct, error) {
+	data, err := json.Marshal(d.Report())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return structuredevent.ToValue(decoded).GetStructValue(), nil
+}
+
+// Client queries the schema drift report from the proxy
+type Client struct {
+	cc *grpc.ClientConn
+}
+
+func NewClient(cc *grpc.ClientConn) *Client {
+	return &Client{cc: cc}
+}
+
+func (c *Client) GetSchemaDrift(ctx context.Context, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, FullMethod, &emptypb.Empty{}, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,288 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/accountdiff"
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels"
+	"github.com/swarpf/proxy/pkg/runeeval"
+	"github.com/swarpf/proxy/pkg/runlog"
+	"github.com/swarpf/proxy/pkg/schemadrift"
+)
+
+func (s *Store) Name() string {
+	return "store"
+}
+
+func (s *Store) Commands() []string {
+	return []string{"*", events.ErrorNamespace + ".*", events.DerivedNamespace + ".*"}
+}
+
+type gameResponse struct {
+	gamemodels.ApiResponse
+	WizardInfo      *gamemodels.WizardInfo `json:"wizard_info"`
+	UnitList        []json.RawMessage      `json:"unit_list"`
+	UnitInfo        json.RawMessage        `json:"unit_info"`
+	Runes           []json.RawMessage      `json:"runes"`
+	Rune            json.RawMessage        `json:"rune"`
+	ChangedItemList []struct {
+		Type gamemodels.GameItem `json:"type"`
+		Info json.RawMessage     `json:"info"`
+	} `json:"changed_item_list"`
+}
+
+// Handle stores the event in its own transaction, so it is kept even if its game data can't be normalised
+func (s *Store) Handle(ctx context.Context, ev events.ApiEventMsg) error {
+	m := ev.Metadata
+	_, err := s.db.ExecContext(ctx, `INSERT INTO events (command, request, response, session, client_addr, upstream_host,
+		upstream_region, request_start, duration_ns, status_code, error, stored_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		ev.Command, ev.Request, ev.Response, m.Session, m.ClientAddr, m.UpstreamHost, m.UpstreamRegion,
+		formatTime(m.RequestStart), int64(m.Duration), m.StatusCode, m.Error, formatTime(time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to store event: %w", err)
+	}
+
+	if err := s.normalise(ctx, ev); err != nil {
+		s.log.Warn().Err(err).Str("command", ev.Command).Msg("Failed to normalise event, only the event itself was stored")
+	}
+
+	return nil
+}
+
+// normalise stores the game data or account diff of an event in their own tables
+func (s *Store) normalise(ctx context.Context, ev events.ApiEventMsg) error {
+	var store func(ctx context.Context, tx *sql.Tx, ev events.ApiEventMsg) error
+	switch {
+	case events.Namespace(ev.Command) == "":
+		store = s.storeGameData
+	case ev.Command == accountdiff.AccountDiff:
+		store = s.storeAccountDiff
+	default:
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := store(ctx, tx, ev); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// storeGameData stores the normalised game objects of a game api event
+func (s *Store) storeGameData(ctx context.Context, tx *sql.Tx, ev events.ApiEventMsg) error {
+	var response gameResponse
+	if err := json.Unmarshal([]byte(ev.Response), &response); err != nil {
+		return err
+	}
+	if response.RetCode != 0 {
+		return nil
+	}
+
+	if response.WizardInfo != nil && (ev.Command == "HubUserLogin" || ev.Command == "GetWizardInfo") {
+		w := response.WizardInfo
+		_, err := tx.ExecContext(ctx, `INSERT INTO wizard_snapshots (wizard_id, wizard_name, level, mana, crystal,
+			energy, command, taken_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			w.WizardId, w.WizardName, w.WizardLevel, w.WizardMana, w.WizardCrystal, w.WizardEnergy, ev.Command,
+			formatTime(time.Now()))
+		if err != nil {
+			return err
+		}
+	}
+
+	units := response.UnitList
+	if len(response.UnitInfo) > 0 && string(response.UnitInfo) != "null" {
+		units = append(units, response.UnitInfo)
+	}
+	for _, rawUnit := range units {
+		var unit gamemodels.Unit
+		if err := json.Unmarshal(rawUnit, &unit); err != nil {
+			return err
+		}
+		if err := s.storeUnit(ctx, tx, unit, rawUnit); err != nil {
+			return err
+		}
+	}
+
+	runes := response.Runes
+	if len(response.Rune) > 0 && string(response.Rune) != "null" {
+		runes = append(runes, response.Rune)
+	}
+	for _, changed := range response.ChangedItemList {
+		if changed.Type == gamemodels.CategoryRune {
+			runes = append(runes, changed.Info)
+		}
+	}
+	for _, rawRune := range runes {
+		var r gamemodels.Rune
+		if err := json.Unmarshal(rawRune, &r); err != nil {
+			return err
+		}
+		if err := s.storeRune(ctx, tx, r, rawRune); err != nil {
+			return err
+		}
+	}
+
+	if ev.Command == "SellRune" {
+		var request struct {
+			RuneIdList []int `json:"rune_id_list"`
+		}
+		if err := json.Unmarshal([]byte(ev.Request), &request); err != nil {
+			return err
+		}
+		for _, runeId := range request.RuneIdList {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM runes WHERE rune_id = ?`, runeId); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// storeUnit stores a unit with its runes. The name is NULL if the monster is unknown, query those units by
+// unit_master_id or load a complete monster master data table.
+func (s *Store) storeUnit(ctx context.Context, tx *sql.Tx, unit gamemodels.Unit, data json.RawMessage) error {
+	var name interface{}
+	if monster, ok := unit.Monster(); ok {
+		name = monster.Name
+	}
+
+	_, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO units (unit_id, wizard_id, unit_master_id, name, attribute,
+		level, stars, data, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		unit.UnitId, unit.WizardId, unit.UnitMasterId, name, unit.Attribute.String(), unit.Level, unit.Stars,
+		string(data), formatTime(time.Now()))
+	if err != nil {
+		return err
+	}
+
+	// runes are sent with the unit they are equipped on
+	for _, r := range unit.Runes {
+		runeData, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if err := s.storeRune(ctx, tx, r, runeData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) storeRune(ctx context.Context, tx *sql.Tx, r gamemodels.Rune, data json.RawMessage) error {
+	var innateStat, innateValue interface{}
+	if r.InnateStat != nil {
+		innateStat, innateValue = r.InnateStat.EffectType.String(), r.InnateStat.EffectValue
+	}
+	evaluation := runeeval.Evaluate(r)
+
+	_, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO runes (rune_id, wizard_id, occupied_id, rune_set, slot, stars,
+		ancient, level, quality, original_quality, main_stat, main_value, innate_stat, innate_value, efficiency,
+		max_efficiency, data, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.RuneId, r.WizardId, r.OccupiedId, r.RuneSet.String(), r.Slot, r.Stars, r.Ancient, r.Level,
+		r.Quality.String(), r.OriginalQuality.String(), r.MainStat.EffectType.String(), r.MainStat.EffectValue,
+		innateStat, innateValue, evaluation.Efficiency, evaluation.MaxEfficiency, string(data), formatTime(time.Now()))
+	return err
+}
+
+func (s *Store) storeAccountDiff(ctx context.Context, tx *sql.Tx, ev events.ApiEventMsg) error {
+	var diff accountdiff.Diff
+	if err := json.Unmarshal([]byte(ev.Response), &diff); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, `INSERT INTO account_diffs (wizard_id, previous_command, previous_taken_at, command,
+		taken_at, mana_delta, crystal_delta, runes_added, runes_removed, runes_changed, units_gained, units_lost, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		diff.WizardId, diff.PreviousCommand, formatTime(diff.PreviousTakenAt), diff.Command, formatTime(diff.TakenAt),
+		diff.ManaDelta, diff.CrystalDelta, len(diff.RunesAdded), len(diff.RunesRemoved), len(diff.RunesChanged),
+		len(diff.UnitsGained), len(diff.UnitsLost), ev.Response)
+	return err
+}
+
+// LoadSnapshot returns the latest account snapshot of a wizard, see accountdiff.SnapshotStore
+func (s *Store) LoadSnapshot(wizardId int) (accountdiff.Snapshot, bool, error) {
+	var snapshot accountdiff.Snapshot
+
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM account_snapshots WHERE wizard_id = ?`, wizardId).Scan(&data)
+	if err == sql.ErrNoRows {
+		return snapshot, false, nil
+	}
+	if err != nil {
+		return snapshot, false, err
+	}
+
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		return snapshot, false, fmt.Errorf("invalid snapshot of wizard %d: %w", wizardId, err)
+	}
+	return snapshot, true, nil
+}
+
+// SaveSnapshot replaces the account snapshot of a wizard, see accountdiff.SnapshotStore
+func (s *Store) SaveSnapshot(snapshot accountdiff.Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO account_snapshots (wizard_id, command, taken_at, data) VALUES (?, ?, ?, ?)`,
+		snapshot.WizardInfo.WizardId, snapshot.Command, formatTime(snapshot.TakenAt), string(data))
+	return err
+}
+
+// LoadSchemas returns all learned response schemas, see schemadrift.SchemaStore
+func (s *Store) LoadSchemas() ([]*schemadrift.Schema, error) {
+	rows, err := s.db.Query(`SELECT command, data FROM response_schemas`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schemas []*schemadrift.Schema
+	for rows.Next() {
+		var command, data string
+		if err := rows.Scan(&command, &data); err != nil {
+			return nil, err
+		}
+
+		schema := schemadrift.NewSchema(command)
+		if err := json.Unmarshal([]byte(data), schema); err != nil {
+			return nil, fmt.Errorf("invalid schema of %s: %w", command, err)
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, rows.Err()
+}
+
+// SaveSchema replaces the response schema of a command, see schemadrift.SchemaStore
+func (s *Store) SaveSchema(schema *schemadrift.Schema) error {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO response_schemas (command, responses, data, updated_at) VALUES (?, ?, ?, ?)`,
+		schema.Command, schema.Responses, string(data), formatTime(time.Now()))
+	return err
+}
+
+// WriteRun stores a run, see runlog.Sink
+func (s *Store) WriteRun(run runlog.Run) error {
+	_, err := s.db.Exec(`INSERT INTO runs (kind, dungeon_id, stage_id, battle_key, start, duration_ns, win,
+		energy_spent, mana, crystal, runes, crafts, essences, scrolls, monsters, other)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.Kind, run.DungeonId, run.StageId, run.BattleKey, formatTime(run.Start), int64(run.Duration), run.Win,
+		run.EnergySpent, run.Mana, run.Crystal, run.Runes, run.Crafts, run.Essences, run.Scrolls, run.Monsters,
+		run.Other)
+	return err
+}
@@ -0,0 +1,238 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	// pure go sqlite driver, the proxy is built without cgo
+	_ "modernc.org/sqlite"
+)
+
+// schema of the store. Tables are only ever added, so stores of older versions can still be opened.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		command TEXT NOT NULL,
+		request TEXT NOT NULL,
+		response TEXT NOT NULL,
+		session INTEGER,
+		client_addr TEXT,
+		upstream_host TEXT,
+		upstream_region TEXT,
+		request_start TIMESTAMP,
+		duration_ns INTEGER,
+		status_code INTEGER,
+		error TEXT,
+		stored_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS events_command ON events (command)`,
+	`CREATE TABLE IF NOT EXISTS runes (
+		rune_id INTEGER PRIMARY KEY,
+		wizard_id INTEGER,
+		occupied_id INTEGER,
+		rune_set TEXT,
+		slot INTEGER,
+		stars INTEGER,
+		ancient BOOLEAN,
+		level INTEGER,
+		quality TEXT,
+		original_quality TEXT,
+		main_stat TEXT,
+		main_value INTEGER,
+		innate_stat TEXT,
+		innate_value INTEGER,
+		efficiency REAL,
+		max_efficiency REAL,
+		data TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS units (
+		unit_id INTEGER PRIMARY KEY,
+		wizard_id INTEGER,
+		unit_master_id INTEGER,
+		-- NULL if the monster is not in the monster master data
+		name TEXT,
+		attribute TEXT,
+		level INTEGER,
+		stars INTEGER,
+		data TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		kind TEXT NOT NULL,
+		dungeon_id INTEGER,
+		stage_id INTEGER,
+		battle_key INTEGER,
+		start TIMESTAMP,
+		duration_ns INTEGER,
+		win BOOLEAN,
+		energy_spent INTEGER,
+		mana INTEGER,
+		crystal INTEGER,
+		runes INTEGER,
+		crafts INTEGER,
+		essences INTEGER,
+		scrolls INTEGER,
+		monsters INTEGER,
+		other INTEGER
+	)`,
+	`CREATE TABLE IF NOT EXISTS wizard_snapshots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		wizard_id INTEGER NOT NULL,
+		wizard_name TEXT,
+		level INTEGER,
+		mana INTEGER,
+		crystal INTEGER,
+		energy INTEGER,
+		command TEXT NOT NULL,
+		taken_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS account_snapshots (
+		wizard_id INTEGER PRIMARY KEY,
+		command TEXT NOT NULL,
+		taken_at TIMESTAMP NOT NULL,
+		data TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS account_diffs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		wizard_id INTEGER NOT NULL,
+		previous_command TEXT,
+		previous_taken_at TIMESTAMP,
+		command TEXT NOT NULL,
+		taken_at TIMESTAMP NOT NULL,
+		mana_delta INTEGER,
+		crystal_delta INTEGER,
+		runes_added INTEGER,
+		runes_removed INTEGER,
+		runes_changed INTEGER,
+		units_gained INTEGER,
+		units_lost INTEGER,
+		data TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS response_schemas (
+		command TEXT PRIMARY KEY,
+		responses INTEGER NOT NULL,
+		data TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+}
+
+// Store persists api events and normalised game data in an embedded sqlite database. All events, including
+// error and derived events, are stored as they are. Runes, units, wizard snapshots and account diffs are
+// additionally stored in their own tables, runs are stored by adding the store as sink of a runlog.Logger and
+// account snapshots and response schemas by passing it to accountdiff.New and schemadrift.New.
+//
+// Store is an in-process plugin, see pmanager.Plugin.
+type Store struct {
+	log zerolog.Logger
+	db  *sql.DB
+}
+
+// Open opens the store at file and creates it if it doesn't exist
+func Open(file string) (*Store, error) {
+	db, err := sql.Open("sqlite", file)
+	if err != nil {
+		return nil, err
+	}
+	// sqlite only supports a single writer, the proxy and `proxy query` are separated by the WAL
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range append([]string{"PRAGMA journal_mode=WAL", "PRAGMA busy_timeout=5000"}, schema...) {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to initialize store: %w", err)
+		}
+	}
+
+	return &Store{
+		log: log.With().Timestamp().Str("log_type", "module").Str("module", "Store").Logger(),
+		db:  db,
+	}, nil
+}
+
+// OpenReadOnly opens an existing store for queries. The database is opened in read-only mode and the schema is
+// neither created nor migrated, so it can be used while the proxy writes to the store.
+func OpenReadOnly(file string) (*Store, error) {
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: file}).EscapedPath()+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range []string{"PRAGMA query_only=1", "PRAGMA busy_timeout=5000"} {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to open store: %w", err)
+		}
+	}
+
+	return &Store{
+		log: log.With().Timestamp().Str("log_type", "module").Str("module", "Store").Logger(),
+		db:  db,
+	}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// QueryResult contains the columns and rows of a query
+type QueryResult struct {
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// Query runs a SQL query against the store. The query is run in a transaction that is always rolled back,
+// so it can't change the store. Open the store with OpenReadOnly to run queries of users.
+func (s *Store) Query(ctx context.Context, query string, args ...interface{}) (QueryResult, error) {
+	var result QueryResult
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	if result.Columns, err = rows.Columns(); err != nil {
+		return result, err
+	}
+	for rows.Next() {
+		values := make([]interface{}, len(result.Columns))
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return result, err
+		}
+
+		for i, value := range values {
+			if b, ok := value.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+
+	return result, rows.Err()
+}
+
+// formatTime returns t in the format used for all timestamps of the store, zero times are stored as null
+func formatTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
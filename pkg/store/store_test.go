@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelstest"
+)
+
+func openStore(t *testing.T) (*Store, string) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	file := filepath.Join(dir, "proxy store.db")
+	s, err := Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s, file
+}
+
+func count(t *testing.T, s *Store, table string) int64 {
+	t.Helper()
+
+	result, err := s.Query(context.Background(), "SELECT COUNT(*) FROM "+table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.Rows[0][0].(int64)
+}
+
+func TestHandle(t *testing.T) {
+	s, _ := openStore(t)
+	ctx := context.Background()
+
+	// the game data of the event can't be normalised, the event is stored anyway
+	invalid := gamemodelstest.Event(t, "GetWizardInfo")
+	invalid.Response = strings.Replace(invalid.Response, `"wizard_id": 12345678`, `"wizard_id": "one"`, 1)
+	gamemodelstest.Handle(t, s,
+		gamemodelstest.Event(t, "HubUserLogin"),
+		gamemodelstest.Event(t, "SummonUnit"),
+		invalid,
+		events.ApiEventMsg{Command: events.ErrorCommand(events.StageEmptyResponse), Request: `{}`},
+	)
+
+	tests := []struct {
+		table string
+		want  int64
+	}{
+		{"events", 4},
+		{"wizard_snapshots", 1},
+		{"units", 4},
+		{"runes", 5},
+	}
+	for _, tt := range tests {
+		if got := count(t, s, tt.table); got != tt.want {
+			t.Errorf("%s has %d rows, want %d", tt.table, got, tt.want)
+		}
+	}
+
+	result, err := s.Query(ctx, "SELECT name FROM units WHERE unit_id IN (9000000004, 9000000005) ORDER BY unit_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Rows[0][0] != "Wind Joker" || result.Rows[1][0] != nil {
+		t.Errorf("names of the summoned units = %v, want Wind Joker and NULL for the unknown monster", result.Rows)
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	s, file := openStore(t)
+	ctx := context.Background()
+
+	gamemodelstest.Handle(t, s, gamemodelstest.Event(t, "GetWizardInfo"))
+
+	// the store is read while the proxy has it open
+	ro, err := OpenReadOnly(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := count(t, ro, "events"); got != 1 {
+		t.Errorf("events has %d rows, want 1", got)
+	}
+	for _, query := range []string{
+		"DELETE FROM events",
+		"CREATE TABLE notes (note TEXT)",
+	} {
+		if _, err := ro.Query(ctx, query); err == nil {
+			t.Errorf("Query(%s) succeeded on a read-only store", query)
+		}
+	}
+
+	if err := ro.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the store is read after the proxy closed it
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	closed, err := OpenReadOnly(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closed.Close()
+	if got := count(t, closed, "events"); got != 1 {
+		t.Errorf("events has %d rows after the store was closed, want 1", got)
+	}
+
+	if _, err := OpenReadOnly(filepath.Join(filepath.Dir(file), "missing.db")); err == nil {
+		t.Error("OpenReadOnly() created a missing store")
+	}
+}
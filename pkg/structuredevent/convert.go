@@ -0,0 +1,175 @@
+package structuredevent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelspb"
+)
+
+const typeUrlPrefix = "type.googleapis.com/"
+
+// New builds the structured representation of an api event. request and response are the decoded payloads
+// of the event, e.g. from eventfilter.Document.
+func New(msg events.ApiEventMsg, request, response interface{}) *structpb.Struct {
+	fields := map[string]*structpb.Value{
+		"command":       stringValue(msg.Command),
+		"request":       stringValue(msg.Request),
+		"response":      stringValue(msg.Response),
+		"request_data":  ToValue(request),
+		"response_data": ToValue(response),
+		"metadata":      metadataValue(msg.Metadata),
+	}
+	if message := responseMessageValue(msg); message != nil {
+		fields["response_message"] = message
+	}
+	return &structpb.Struct{Fields: fields}
+}
+
+// Decode returns the api event and the decoded payloads of a structured event
+func Decode(ev *structpb.Struct) (msg events.ApiEventMsg, request, response interface{}) {
+	fields := ev.GetFields()
+	metadata := map[string]string{}
+	for key, value := range fields["metadata"].GetStructValue().GetFields() {
+		metadata[key] = value.GetStringValue()
+	}
+
+	msg = events.ApiEventMsg{
+		Command:  fields["command"].GetStringValue(),
+		Request:  fields["request"].GetStringValue(),
+		Response: fields["response"].GetStringValue(),
+		Metadata: events.ParseExchangeMetadata(metadata),
+	}
+	return msg, FromValue(fields["request_data"]), FromValue(fields["response_data"])
+}
+
+// ResponseMessage returns the typed message of the response of a structured event, see gamemodelspb. It returns
+// nil without an error if the event has none.
+func ResponseMessage(ev *structpb.Struct) (proto.Message, error) {
+	value, ok := ev.GetFields()["response_message"]
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := protojson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var envelope anypb.Any
+	if err := protojson.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid response message: %w", err)
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByURL(envelope.GetTypeUrl())
+	if err != nil {
+		return nil, err
+	}
+	message := messageType.New().Interface()
+	if err := proto.Unmarshal(envelope.GetValue(), message); err != nil {
+		return nil, fmt.Errorf("invalid response message: %w", err)
+	}
+	return message, nil
+}
+
+// responseMessageValue returns the typed message of the response in the JSON mapping of google.protobuf.Any. It
+// returns nil for commands that aren't well-known and for responses that can't be decoded.
+func responseMessageValue(msg events.ApiEventMsg) *structpb.Value {
+	message, err := gamemodelspb.NewResponse(msg.Command, msg.Response)
+	if err != nil || message == nil {
+		return nil
+	}
+
+	encoded, err := proto.Marshal(message)
+	if err != nil {
+		return nil
+	}
+	data, err := protojson.Marshal(&anypb.Any{
+		TypeUrl: typeUrlPrefix + string(message.ProtoReflect().Descriptor().FullName()),
+		Value:   encoded,
+	})
+	if err != nil {
+		return nil
+	}
+
+	var value structpb.Value
+	if err := protojson.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+	return &value
+}
+
+// ToValue converts a value decoded by encoding/json into a protobuf value
+func ToValue(v interface{}) *structpb.Value {
+	switch v := v.(type) {
+	case nil:
+		return &structpb.Value{Kind: &structpb.Value_NullValue{}}
+	case bool:
+		return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: v}}
+	case float64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: v}}
+	case json.Number:
+		f, _ := v.Float64()
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: f}}
+	case string:
+		return stringValue(v)
+	case []interface{}:
+		values := make([]*structpb.Value, len(v))
+		for i, element := range v {
+			values[i] = ToValue(element)
+		}
+		return &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: values}}}
+	case map[string]interface{}:
+		fields := make(map[string]*structpb.Value, len(v))
+		for key, element := range v {
+			fields[key] = ToValue(element)
+		}
+		return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: fields}}}
+	}
+
+	return &structpb.Value{Kind: &structpb.Value_NullValue{}}
+}
+
+// FromValue converts a protobuf value back into the representation used by encoding/json
+func FromValue(v *structpb.Value) interface{} {
+	switch kind := v.GetKind().(type) {
+	case *structpb.Value_BoolValue:
+		return kind.BoolValue
+	case *structpb.Value_NumberValue:
+		return kind.NumberValue
+	case *structpb.Value_StringValue:
+		return kind.StringValue
+	case *structpb.Value_ListValue:
+		values := make([]interface{}, len(kind.ListValue.GetValues()))
+		for i, element := range kind.ListValue.GetValues() {
+			values[i] = FromValue(element)
+		}
+		return values
+	case *structpb.Value_StructValue:
+		fields := make(map[string]interface{}, len(kind.StructValue.GetFields()))
+		for key, element := range kind.StructValue.GetFields() {
+			fields[key] = FromValue(element)
+		}
+		return fields
+	}
+
+	return nil
+}
+
+func metadataValue(m events.ExchangeMetadata) *structpb.Value {
+	fields := map[string]*structpb.Value{}
+	for key, value := range m.Strings() {
+		fields[key] = stringValue(value)
+	}
+	return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: fields}}}
+}
+
+func stringValue(s string) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}
+}
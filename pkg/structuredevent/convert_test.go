@@ -0,0 +1,114 @@
+package structuredevent
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelspb"
+)
+
+func TestNewDecode(t *testing.T) {
+	msg := events.ApiEventMsg{
+		Command:  "HubUserLogin",
+		Request:  `{"command": "HubUserLogin"}`,
+		Response: `{"ret_code":0,"wizard_info":{"wizard_id":1},"unit_list":[{"unit_id":2}],"flag":true,"none":null}`,
+		Metadata: events.ExchangeMetadata{UpstreamHost: "summonerswar-gb.qpyou.cn", StatusCode: 200,
+			RequestStart: time.Unix(1600000000, 0).UTC()},
+	}
+	request := map[string]interface{}{"command": "HubUserLogin"}
+	response := map[string]interface{}{
+		"ret_code":    float64(0),
+		"wizard_info": map[string]interface{}{"wizard_id": float64(1)},
+		"unit_list":   []interface{}{map[string]interface{}{"unit_id": float64(2)}},
+		"flag":        true,
+		"none":        nil,
+	}
+
+	ev := New(msg, request, response)
+	if ev.Fields["request"].GetStringValue() != msg.Request {
+		t.Errorf("request = %v, want the raw request", ev.Fields["request"])
+	}
+	if ev.Fields["request_data"].GetStructValue() == nil {
+		t.Errorf("request_data = %v, want the decoded payload", ev.Fields["request_data"])
+	}
+
+	decoded, decodedRequest, decodedResponse := Decode(ev)
+	if !reflect.DeepEqual(decodedRequest, request) {
+		t.Errorf("request = %v, want %v", decodedRequest, request)
+	}
+	if !reflect.DeepEqual(decodedResponse, response) {
+		t.Errorf("response = %v, want %v", decodedResponse, response)
+	}
+	if decoded.Command != msg.Command {
+		t.Errorf("Command = %q, want %q", decoded.Command, msg.Command)
+	}
+	if decoded.Request != msg.Request || decoded.Response != msg.Response {
+		t.Errorf("Request = %s, Response = %s, want the raw payloads", decoded.Request, decoded.Response)
+	}
+	if decoded.Metadata.UpstreamHost != msg.Metadata.UpstreamHost || decoded.Metadata.StatusCode != 200 ||
+		!decoded.Metadata.RequestStart.Equal(msg.Metadata.RequestStart) {
+		t.Errorf("Metadata = %+v, want %+v", decoded.Metadata, msg.Metadata)
+	}
+}
+
+func TestDecodeInvalidPayload(t *testing.T) {
+	ev := New(events.ApiEventMsg{Command: "HubUserLogin", Request: "{", Response: "{"}, nil, nil)
+	msg, request, response := Decode(ev)
+	if request != nil || response != nil {
+		t.Errorf("payloads = %v, %v, want nil", request, response)
+	}
+	if msg.Request != "{" || msg.Response != "{" {
+		t.Errorf("Request = %s, Response = %s, want the raw payloads", msg.Request, msg.Response)
+	}
+
+	message, err := ResponseMessage(ev)
+	if message != nil || err != nil {
+		t.Errorf("ResponseMessage() = %v, %v, want no message", message, err)
+	}
+}
+
+func TestResponseMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		response string
+		want     proto.Message
+	}{
+		{
+			name:     "well-known command",
+			command:  "HubUserLogin",
+			response: `{"command":"HubUserLogin","wizard_info":{"wizard_id":1},"unit_list":[{"unit_id":2,"class":6}]}`,
+			want: &gamemodelspb.HubUserLogin{
+				Command:    "HubUserLogin",
+				WizardInfo: &gamemodelspb.WizardInfo{WizardId: 1},
+				UnitList:   []*gamemodelspb.Unit{{UnitId: 2, Stars: 6}},
+			},
+		},
+		{
+			name:     "other command",
+			command:  "GetNoticeChat",
+			response: `{"command":"GetNoticeChat"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev := New(events.ApiEventMsg{Command: tt.command, Response: tt.response}, nil, nil)
+			if _, ok := ev.Fields["response_message"]; ok != (tt.want != nil) {
+				t.Errorf("response_message is set = %v, want %v", ok, tt.want != nil)
+			}
+
+			message, err := ResponseMessage(ev)
+			if err != nil {
+				t.Fatalf("ResponseMessage() error = %v", err)
+			}
+			if tt.want == nil && message != nil || tt.want != nil && !proto.Equal(message, tt.want) {
+				t.Errorf("ResponseMessage() = %v, want %v", message, tt.want)
+			}
+		})
+	}
+}
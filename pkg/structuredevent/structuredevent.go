@@ -0,0 +1,95 @@
+package structuredevent
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// The structured consumer service is an alternative to ProxyApiConsumer.OnReceiveApiEvent for consumers that
+// don't want to parse the JSON payloads themselves. It only uses well-known protobuf types, so it can be
+// implemented in any language without additional generated code:
+//
+//	service StructuredApiConsumer {
+//	  rpc OnReceiveStructuredApiEvent(google.protobuf.Struct) returns (google.protobuf.Empty);
+//	}
+//
+// The event struct has the fields `command`, `request` and `response` with the raw JSON strings and
+// `request_data` and `response_data` with the decoded payloads (null if they aren't valid JSON). The field
+// `metadata` describes the HTTP exchange, see events.ExchangeMetadata.Strings.
+//
+// For the well-known commands the field `response_message` has the typed message of the response from
+// gamemodels.proto (see gamemodelspb) in the JSON mapping of google.protobuf.Any. Consumers that compiled
+// gamemodels.proto can parse it into the message, see ResponseMessage.
+const (
+	ServiceName = "swarpf.proxyapi.StructuredApiConsumer"
+	MethodName  = "OnReceiveStructuredApiEvent"
+
+	// PayloadMetadataKey is the grpc metadata key consumers use to select the payload format on registration
+	PayloadMetadataKey = "x-swarpf-payload"
+	// PayloadStructured selects the structured consumer service
+	PayloadStructured = "structured"
+)
+
+// Server is implemented by consumers of structured api events
+type Server interface {
+	OnReceiveStructuredApiEvent(ctx context.Context, ev *structpb.Struct) (*emptypb.Empty, error)
+}
+
+// RegisterServer registers the structured consumer service at s
+func RegisterServer(s *grpc.Server, srv Server) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: MethodName,
+			Handler:    handleOnReceiveStructuredApiEvent,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func handleOnReceiveStructuredApiEvent(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Server).OnReceiveStructuredApiEvent(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: fmt.Sprintf("/%s/%s", ServiceName, MethodName),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Server).OnReceiveStructuredApiEvent(ctx, req.(*structpb.Struct))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Client sends structured api events to a consumer
+type Client struct {
+	cc *grpc.ClientConn
+}
+
+func NewClient(cc *grpc.ClientConn) *Client {
+	return &Client{cc: cc}
+}
+
+func (c *Client) OnReceiveStructuredApiEvent(ctx context.Context, ev *structpb.Struct,
+	opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.cc.Invoke(ctx, fmt.Sprintf("/%s/%s", ServiceName, MethodName), ev, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,385 @@
+package swexport
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/swarpf/proxy/pkg/gamemodels"
+)
+
+// object is a game object as it was sent by the game api, numbers are kept as json.Number
+type object map[string]interface{}
+
+// unit is a unit of a profile with its equipped runes and artifacts
+type unit struct {
+	object    object
+	runes     []object
+	artifacts []object
+	// runesBySlot and artifactsBySlot are set if the game sent them as objects keyed by slot
+	runesBySlot     bool
+	artifactsBySlot bool
+}
+
+// profile is the login response of a wizard with all later changes to its units, runes and artifacts applied
+type profile struct {
+	login     object
+	units     map[int]*unit
+	runes     map[int]object
+	artifacts map[int]object
+}
+
+// newProfile splits the login response into the game objects that are updated by later responses
+func newProfile(login object) *profile {
+	p := &profile{
+		login:     login,
+		units:     map[int]*unit{},
+		runes:     map[int]object{},
+		artifacts: map[int]object{},
+	}
+	for _, u := range objectList(login["unit_list"]) {
+		p.setUnit(u)
+	}
+	for _, r := range objectList(login["runes"]) {
+		p.setRune(r)
+	}
+	for _, artifact := range objectList(login["artifacts"]) {
+		p.setArtifact(artifact)
+	}
+	return p
+}
+
+// apply updates the profile with all game objects of an exchange and returns true if the profile changed
+func (p *profile) apply(command string, request, response object) bool {
+	changed := false
+
+	if wizardInfo, ok := response["wizard_info"].(map[string]interface{}); ok {
+		p.login["wizard_info"] = wizardInfo
+		changed = true
+	}
+
+	// feeding material is consumed, its runes are moved to the inventory
+	for _, material := range objectList(request["source_unit_list"]) {
+		changed = p.removeUnit(toInt(material["source_unit_id"])) || changed
+	}
+	if command == "SellRune" {
+		ids, _ := request["rune_id_list"].([]interface{})
+		for _, id := range ids {
+			if _, ok := p.runes[toInt(id)]; ok {
+				delete(p.runes, toInt(id))
+				changed = true
+			}
+		}
+	}
+
+	for _, u := range objectList(response["unit_list"]) {
+		changed = p.setUnit(u) || changed
+	}
+	if u, ok := response["unit_info"].(map[string]interface{}); ok {
+		changed = p.setUnit(u) || changed
+	}
+	for _, r := range objectList(response["runes"]) {
+		changed = p.setRune(r) || changed
+	}
+	if r, ok := response["rune"].(map[string]interface{}); ok {
+		changed = p.setRune(r) || changed
+	}
+	for _, artifact := range objectList(response["artifacts"]) {
+		changed = p.setArtifact(artifact) || changed
+	}
+	if artifact, ok := response["artifact"].(map[string]interface{}); ok {
+		changed = p.setArtifact(artifact) || changed
+	}
+
+	for _, item := range objectList(response["changed_item_list"]) {
+		info, ok := item["info"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch gamemodels.GameItem(toInt(item["type"])) {
+		case gamemodels.CategoryMonster:
+			changed = p.setUnit(info) || changed
+		case gamemodels.CategoryRune:
+			changed = p.setRune(info) || changed
+		}
+	}
+
+	return changed
+}
+
+// setUnit stores a unit. Runes and artifacts that are no longer equipped are only moved to the inventory if the
+// unit was sent with its runes (or artifacts).
+func (p *profile) setUnit(o object) bool {
+	unitId := toInt(o["unit_id"])
+	if unitId == 0 {
+		return false
+	}
+
+	u := &unit{object: object{}}
+	for key, value := range o {
+		if key != "runes" && key != "artifacts" {
+			u.object[key] = value
+		}
+	}
+	if previous, ok := p.units[unitId]; ok {
+		u.runes, u.runesBySlot = previous.runes, previous.runesBySlot
+		u.artifacts, u.artifactsBySlot = previous.artifacts, previous.artifactsBySlot
+	}
+
+	if runes, ok := o["runes"]; ok {
+		u.runes = objectList(runes)
+		_, u.runesBySlot = runes.(map[string]interface{})
+		for _, r := range u.runes {
+			delete(p.runes, toInt(r["rune_id"]))
+		}
+		if previous, ok := p.units[unitId]; ok {
+			p.unequip(previous.runes, u.runes, "rune_id", p.runes)
+		}
+	}
+	if artifacts, ok := o["artifacts"]; ok {
+		u.artifacts = objectList(artifacts)
+		_, u.artifactsBySlot = artifacts.(map[string]interface{})
+		for _, artifact := range u.artifacts {
+			delete(p.artifacts, toInt(artifact["rid"]))
+		}
+		if previous, ok := p.units[unitId]; ok {
+			p.unequip(previous.artifacts, u.artifacts, "rid", p.artifacts)
+		}
+	}
+
+	p.units[unitId] = u
+	return true
+}
+
+// unequip moves the objects of previous that are not in current to the inventory
+func (p *profile) unequip(previous, current []object, idKey string, inventory map[int]object) {
+	equipped := map[int]bool{}
+	for _, o := range current {
+		equipped[toInt(o[idKey])] = true
+	}
+	for _, o := range previous {
+		if id := toInt(o[idKey]); !equipped[id] {
+			inventory[id] = unequipped(o)
+		}
+	}
+}
+
+func (p *profile) removeUnit(unitId int) bool {
+	u, ok := p.units[unitId]
+	if !ok {
+		return false
+	}
+
+	delete(p.units, unitId)
+	for _, r := range u.runes {
+		p.runes[toInt(r["rune_id"])] = unequipped(r)
+	}
+	for _, artifact := range u.artifacts {
+		p.artifacts[toInt(artifact["rid"])] = unequipped(artifact)
+	}
+	return true
+}
+
+// setRune stores a rune in the inventory or replaces it on the unit it is equipped on
+func (p *profile) setRune(r object) bool {
+	runeId := toInt(r["rune_id"])
+	if runeId == 0 {
+		return false
+	}
+
+	p.removeEquipped(runeId, "rune_id", func(u *unit) *[]object { return &u.runes })
+	if u, ok := p.units[toInt(r["occupied_id"])]; ok {
+		u.runes = append(u.runes, r)
+		delete(p.runes, runeId)
+	} else {
+		p.runes[runeId] = r
+	}
+	return true
+}
+
+// setArtifact stores an artifact in the inventory or replaces it on the unit it is equipped on
+func (p *profile) setArtifact(artifact object) bool {
+	artifactId := toInt(artifact["rid"])
+	if artifactId == 0 {
+		return false
+	}
+
+	p.removeEquipped(artifactId, "rid", func(u *unit) *[]object { return &u.artifacts })
+	if u, ok := p.units[toInt(artifact["occupied_id"])]; ok {
+		u.artifacts = append(u.artifacts, artifact)
+		delete(p.artifacts, artifactId)
+	} else {
+		p.artifacts[artifactId] = artifact
+	}
+	return true
+}
+
+// removeEquipped removes an object from the unit it is equipped on
+func (p *profile) removeEquipped(id int, idKey string, list func(u *unit) *[]object) {
+	for _, u := range p.units {
+		objects := list(u)
+		for i, o := range *objects {
+			if toInt(o[idKey]) == id {
+				*objects = append(append([]object(nil), (*objects)[:i]...), (*objects)[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// export returns the profile in the format of the login response. If normalize is set, the runes and artifacts
+// of all units are arrays ordered by slot, otherwise they are encoded like the game sent them.
+func (p *profile) export(normalize bool) (object, error) {
+	exported := make(object, len(p.login))
+	for key, value := range p.login {
+		exported[key] = value
+	}
+
+	unitIds := make([]int, 0, len(p.units))
+	for unitId := range p.units {
+		unitIds = append(unitIds, unitId)
+	}
+	sort.Ints(unitIds)
+
+	units := make([]interface{}, 0, len(unitIds))
+	for _, unitId := range unitIds {
+		u := p.units[unitId]
+
+		runes, err := slotted(u.runes, u.runesBySlot && !normalize, decodeRuneSlot)
+		if err != nil {
+			return nil, err
+		}
+		artifacts, err := slotted(u.artifacts, u.artifactsBySlot && !normalize, decodeArtifactSlot)
+		if err != nil {
+			return nil, err
+		}
+
+		o := make(object, len(u.object)+2)
+		for key, value := range u.object {
+			o[key] = value
+		}
+		o["runes"], o["artifacts"] = runes, artifacts
+		units = append(units, o)
+	}
+	exported["unit_list"] = units
+	exported["runes"] = sortedById(p.runes)
+	exported["artifacts"] = sortedById(p.artifacts)
+
+	return exported, nil
+}
+
+// slotted orders the objects by the slot of their game model and returns them as array or as object keyed by slot
+func slotted(objects []object, bySlot bool, decodeSlot func(o object) (int, error)) (interface{}, error) {
+	type slottedObject struct {
+		slot   int
+		object object
+	}
+
+	ordered := make([]slottedObject, len(objects))
+	for i, o := range objects {
+		slot, err := decodeSlot(o)
+		if err != nil {
+			return nil, err
+		}
+		ordered[i] = slottedObject{slot: slot, object: o}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].slot < ordered[j].slot })
+
+	if bySlot {
+		keyed := make(map[string]interface{}, len(ordered))
+		for _, o := range ordered {
+			keyed[strconv.Itoa(o.slot)] = o.object
+		}
+		return keyed, nil
+	}
+
+	list := make([]interface{}, 0, len(ordered))
+	for _, o := range ordered {
+		list = append(list, o.object)
+	}
+	return list, nil
+}
+
+func decodeRuneSlot(o object) (int, error) {
+	var r gamemodels.Rune
+	if err := remarshal(o, &r); err != nil {
+		return 0, err
+	}
+	return r.Slot, nil
+}
+
+func decodeArtifactSlot(o object) (int, error) {
+	var artifact gamemodels.Artifact
+	if err := remarshal(o, &artifact); err != nil {
+		return 0, err
+	}
+	return artifact.Slot, nil
+}
+
+func sortedById(objects map[int]object) []interface{} {
+	ids := make([]int, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	list := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, objects[id])
+	}
+	return list
+}
+
+// unequipped returns a copy of an equipped rune or artifact that is in the inventory
+func unequipped(o object) object {
+	c := make(object, len(o))
+	for key, value := range o {
+		c[key] = value
+	}
+	c["occupied_id"] = json.Number("0")
+	return c
+}
+
+// objectList returns the objects of a json array or of a json object keyed by e.g. the rune slot
+func objectList(v interface{}) []object {
+	var objects []object
+	switch v := v.(type) {
+	case []interface{}:
+		for _, element := range v {
+			if o, ok := element.(map[string]interface{}); ok {
+				objects = append(objects, o)
+			}
+		}
+	case map[string]interface{}:
+		for _, element := range v {
+			if o, ok := element.(map[string]interface{}); ok {
+				objects = append(objects, o)
+			}
+		}
+	}
+	return objects
+}
+
+// toInt returns the integer value of a number, the game api sends numbers as json numbers or strings
+func toInt(v interface{}) int {
+	switch v := v.(type) {
+	case json.Number:
+		i, _ := strconv.Atoi(v.String())
+		return i
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
+
+// remarshal decodes a decoded json value into out
+func remarshal(v interface{}, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,174 @@
+package swexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+type Configuration struct {
+	// Directory the profiles are written to
+	Directory string
+	// Normalize unit runes and artifacts to arrays ordered by slot, the game sends them as arrays or as objects
+	// keyed by slot
+	Normalize bool
+}
+
+// Exporter writes the HubUserLogin response to `<Directory>/<wizard_id>.json` in the profile format of the
+// SW Exporter that most optimizers can import. Later responses that change the units, runes or artifacts of the
+// wizard are applied to the profile and it is written again, so it stays up to date until the next login.
+//
+// Exporter is an in-process plugin, see pmanager.Plugin.
+type Exporter struct {
+	log           zerolog.Logger
+	configuration Configuration
+
+	// profiles by wizard, they are only used by the plugin goroutine
+	profiles map[int]*profile
+	// lastWizardId is the wizard of the latest login, requests without a wizard_id belong to it
+	lastWizardId int
+}
+
+func New(configuration Configuration) (*Exporter, error) {
+	if configuration.Directory == "" {
+		return nil, errors.New("no export directory")
+	}
+	if err := os.MkdirAll(configuration.Directory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	return &Exporter{
+		log:           log.With().Timestamp().Str("log_type", "module").Str("module", "SwexExport").Logger(),
+		configuration: configuration,
+		profiles:      map[int]*profile{},
+	}, nil
+}
+
+func (e *Exporter) Name() string {
+	return "swexport"
+}
+
+// Commands subscribes to all game commands, game objects are part of the responses of many commands
+func (e *Exporter) Commands() []string {
+	return []string{"*"}
+}
+
+func (e *Exporter) Handle(_ context.Context, ev events.ApiEventMsg) error {
+	if events.Namespace(ev.Command) != "" {
+		return nil
+	}
+
+	// numbers are kept as they were sent by the game
+	response, err := decodeObject(ev.Response)
+	if err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", ev.Command, err)
+	}
+	// failed commands did not change anything
+	if retCode := toInt(response["ret_code"]); retCode != 0 {
+		return nil
+	}
+
+	if ev.Command == "HubUserLogin" {
+		wizardInfo, _ := response["wizard_info"].(map[string]interface{})
+		wizardId := toInt(wizardInfo["wizard_id"])
+		if wizardId == 0 {
+			return nil
+		}
+
+		e.profiles[wizardId] = newProfile(response)
+		e.lastWizardId = wizardId
+		return e.export(wizardId, ev.Command)
+	}
+
+	request, err := decodeObject(ev.Request)
+	if err != nil {
+		return fmt.Errorf("failed to decode request of %s: %w", ev.Command, err)
+	}
+	wizardId := toInt(request["wizard_id"])
+	if wizardId == 0 {
+		wizardId = e.lastWizardId
+	}
+
+	p, ok := e.profiles[wizardId]
+	if !ok || !p.apply(ev.Command, request, response) {
+		return nil
+	}
+	return e.export(wizardId, ev.Command)
+}
+
+// export writes the profile of a wizard, command is the command whose response changed the profile
+func (e *Exporter) export(wizardId int, command string) error {
+	exported, err := e.profiles[wizardId].export(e.configuration.Normalize)
+	if err != nil {
+		return fmt.Errorf("failed to export profile of wizard %d: %w", wizardId, err)
+	}
+
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file := filepath.Join(e.configuration.Directory, strconv.Itoa(wizardId)+".json")
+	if err := writeFile(file, data); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	wizardInfo, _ := exported["wizard_info"].(map[string]interface{})
+	wizardName, _ := wizardInfo["wizard_name"].(string)
+	// profiles are refreshed frequently, only the export on login is worth an info
+	level := zerolog.DebugLevel
+	if command == "HubUserLogin" {
+		level = zerolog.InfoLevel
+	}
+	e.log.WithLevel(level).
+		Int("wizardId", wizardId).
+		Str("command", command).
+		Str("wizardName", wizardName).
+		Str("file", file).
+		Msg("Exported profile")
+
+	return nil
+}
+
+func decodeObject(data string) (object, error) {
+	var o object
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// writeFile replaces file atomically, so optimizers never read a partially written profile
+func writeFile(file string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".profile-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
@@ -0,0 +1,171 @@
+package swexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/swarpf/proxy/pkg/events"
+	"github.com/swarpf/proxy/pkg/gamemodels/gamemodelstest"
+)
+
+type exportedProfile struct {
+	WizardInfo struct {
+		WizardMana int `json:"wizard_mana"`
+	} `json:"wizard_info"`
+	UnitList []struct {
+		UnitId int             `json:"unit_id"`
+		Runes  json.RawMessage `json:"runes"`
+	} `json:"unit_list"`
+	Runes []struct {
+		RuneId     int `json:"rune_id"`
+		OccupiedId int `json:"occupied_id"`
+	} `json:"runes"`
+}
+
+func newExporter(t *testing.T, normalize bool) *Exporter {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "swexport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	e, err := New(Configuration{Directory: dir, Normalize: normalize})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// export handles the events and returns the exported profile of the recorded wizard
+func export(t *testing.T, e *Exporter, evs ...events.ApiEventMsg) exportedProfile {
+	t.Helper()
+
+	gamemodelstest.Handle(t, e, evs...)
+
+	data, err := ioutil.ReadFile(filepath.Join(e.configuration.Directory, strconv.Itoa(gamemodelstest.WizardId)+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p exportedProfile
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// ids returns the unit ids and the ids of the inventory runes of a profile
+func (p exportedProfile) ids() string {
+	var units, runes []int
+	for _, unit := range p.UnitList {
+		units = append(units, unit.UnitId)
+	}
+	for _, r := range p.Runes {
+		runes = append(runes, r.RuneId)
+	}
+	return fmt.Sprintf("units %v, runes %v", units, runes)
+}
+
+// runeIds returns the rune ids of a unit in the order they were exported, slot-keyed runes by their key
+func runeIds(t *testing.T, runes json.RawMessage) interface{} {
+	t.Helper()
+
+	var list []struct {
+		RuneId int `json:"rune_id"`
+	}
+	if err := json.Unmarshal(runes, &list); err == nil {
+		ids := []int{}
+		for _, r := range list {
+			ids = append(ids, r.RuneId)
+		}
+		return ids
+	}
+
+	var bySlot map[string]struct {
+		RuneId int `json:"rune_id"`
+	}
+	if err := json.Unmarshal(runes, &bySlot); err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]int{}
+	for slot, r := range bySlot {
+		ids[slot] = r.RuneId
+	}
+	return ids
+}
+
+func TestExportLogin(t *testing.T) {
+	tests := []struct {
+		normalize bool
+		want      []string
+	}{
+		{false, []string{"[30000000001 30000000002]", "map[4:30000000004 6:30000000006]"}},
+		{true, []string{"[30000000001 30000000002]", "[30000000004 30000000006]"}},
+	}
+	for _, tt := range tests {
+		e := newExporter(t, tt.normalize)
+
+		p := export(t, e, gamemodelstest.Event(t, "HubUserLogin"))
+		if len(p.UnitList) != 2 {
+			t.Fatalf("exported %d units, want 2", len(p.UnitList))
+		}
+		for i, unit := range p.UnitList {
+			if got := fmt.Sprint(runeIds(t, unit.Runes)); got != tt.want[i] {
+				t.Errorf("normalize %v: runes of unit %d = %s, want %s", tt.normalize, unit.UnitId, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestExportRefresh(t *testing.T) {
+	e := newExporter(t, true)
+
+	// the dropped runes and monster, the request has no wizard id
+	result := gamemodelstest.Event(t, "BattleDungeonResult_V2")
+	result.Request = `{"command": "BattleDungeonResult_V2"}`
+	p := export(t, e, gamemodelstest.Event(t, "HubUserLogin"), result)
+	if got, want := p.ids(), "units [9000000001 9000000002 9000000003], runes [30000000010 30000000020 30000000021]"; got != want {
+		t.Errorf("exported %s, want %s", got, want)
+	}
+
+	// rune 30000000002 was removed from unit 9000000001 and rune 30000000010 equipped
+	p = export(t, e, gamemodelstest.Event(t, "UnequipRune"))
+	if p.WizardInfo.WizardMana != 1520040 {
+		t.Errorf("wizard mana = %d, want 1520040", p.WizardInfo.WizardMana)
+	}
+	p = export(t, e, gamemodelstest.Event(t, "EquipRune"))
+	if got := fmt.Sprint(runeIds(t, p.UnitList[0].Runes)); got != "[30000000001 30000000010]" {
+		t.Errorf("runes of unit 9000000001 = %s, want [30000000001 30000000010]", got)
+	}
+	if len(p.Runes) != 3 || p.Runes[0].RuneId != 30000000002 || p.Runes[0].OccupiedId != 0 {
+		t.Errorf("inventory runes = %+v, want the unequipped rune 30000000002 and the dropped runes", p.Runes)
+	}
+
+	// failed commands don't change the profile
+	sale := gamemodelstest.Event(t, "SellRune")
+	p = export(t, e, gamemodelstest.Failed(sale))
+	if got := len(p.Runes); got != 3 {
+		t.Errorf("exported %d inventory runes after a failed sale, want 3", got)
+	}
+
+	// unit 9000000002 was fed to unit 9000000004, its runes are moved to the inventory
+	p = export(t, e, sale, gamemodelstest.Event(t, "UpgradeUnit"))
+	if got, want := p.ids(), "units [9000000001 9000000003 9000000004], runes [30000000004 30000000006 30000000020 30000000021]"; got != want {
+		t.Errorf("exported %s, want %s", got, want)
+	}
+
+	// the refreshed profile is the profile of the next login
+	e = newExporter(t, true)
+	for _, ev := range gamemodelstest.Session(t) {
+		p = export(t, e, ev)
+	}
+	if got, want := p.ids(), export(t, newExporter(t, true), gamemodelstest.Event(t, "HubUserLogin.after")).ids(); got != want {
+		t.Errorf("exported %s after the session, want %s", got, want)
+	}
+}
@@ -0,0 +1,166 @@
+package swproxy
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/elazarl/goproxy"
+)
+
+// ParseAllowedNetwork parses a CIDR network or a single IP address that is allowed to use the proxy
+func ParseAllowedNetwork(network string) (*net.IPNet, error) {
+	if !strings.Contains(network, "/") {
+		ip := net.ParseIP(network)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip address %q", network)
+		}
+
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 8 * net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network %q: %w", network, err)
+	}
+	return ipNet, nil
+}
+
+// interceptedRequestKey marks requests created by the transparent and SOCKS front-ends, which authenticate
+// their clients on their own
+type interceptedRequestKey struct{}
+
+func markIntercepted(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), interceptedRequestKey{}, true))
+}
+
+func isIntercepted(req *http.Request) bool {
+	intercepted, _ := req.Context().Value(interceptedRequestKey{}).(bool)
+	return intercepted
+}
+
+// IsClientAllowed checks if addr is part of the allowed networks, e.g. for other services that are exposed
+// next to the proxy like the DNS responder
+func (p *Proxy) IsClientAllowed(addr string) bool {
+	return p.isClientAllowed(addr)
+}
+
+// isClientAllowed checks if addr is part of the allowed networks. All clients are allowed if no networks are set.
+func (p *Proxy) isClientAllowed(addr string) bool {
+	allowedNetworks := p.config().AllowedNetworks
+	if len(allowedNetworks) == 0 {
+		return true
+	}
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range allowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Proxy) requiresAuthentication() bool {
+	configuration := p.config()
+	return configuration.ProxyUsername != "" || configuration.ProxyPassword != ""
+}
+
+// checkCredentials compares the given credentials with the configured ones in constant time
+func (p *Proxy) checkCredentials(username, password string) bool {
+	configuration := p.config()
+	usernameMatches := subtle.ConstantTimeCompare([]byte(username), []byte(configuration.ProxyUsername)) == 1
+	passwordMatches := subtle.ConstantTimeCompare([]byte(password), []byte(configuration.ProxyPassword)) == 1
+	return usernameMatches && passwordMatches
+}
+
+func (p *Proxy) checkProxyAuthorization(req *http.Request) bool {
+	const prefix = "Basic "
+
+	auth := req.Header.Get("Proxy-Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(auth[len(prefix):])
+	if err != nil {
+		return false
+	}
+
+	credentials := strings.SplitN(string(decoded), ":", 2)
+	if len(credentials) != 2 {
+		return false
+	}
+	return p.checkCredentials(credentials[0], credentials[1])
+}
+
+// accessControl rejects clients outside of the allowed networks and clients of the http proxy that
+// did not authenticate themselves
+func (p *Proxy) accessControl(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if isIntercepted(req) {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if !p.isClientAllowed(req.RemoteAddr) {
+			p.log.Warn().Str("remote_addr", req.RemoteAddr).Str("host", req.Host).Msg("Rejected client outside of the allowed networks")
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		if p.requiresAuthentication() && !p.checkProxyAuthorization(req) {
+			p.log.Warn().Str("remote_addr", req.RemoteAddr).Str("host", req.Host).Msg("Rejected client without valid proxy credentials")
+			w.Header().Set("Proxy-Authenticate", `Basic realm="swarpf"`)
+			http.Error(w, http.StatusText(http.StatusProxyAuthRequired), http.StatusProxyAuthRequired)
+			return
+		}
+
+		// the credentials are meant for us and must not be forwarded
+		req.Header.Del("Proxy-Authorization")
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// Non-game endpoint matcher
+// used to refuse all connections that are neither game traffic nor certificate downloads while GameHostsOnly is set
+type nonGameEndpointMatcher struct {
+	proxy *Proxy
+}
+
+func newNonGameEndpointMatcher(p *Proxy) *nonGameEndpointMatcher {
+	return &nonGameEndpointMatcher{proxy: p}
+}
+
+func (s nonGameEndpointMatcher) HandleReq(_ *http.Request, ctx *goproxy.ProxyCtx) bool {
+	return s.matches(ctx)
+}
+
+func (s nonGameEndpointMatcher) HandleResp(_ *http.Response, ctx *goproxy.ProxyCtx) bool {
+	return s.matches(ctx)
+}
+
+func (s nonGameEndpointMatcher) matches(ctx *goproxy.ProxyCtx) bool {
+	configuration := s.proxy.config()
+	// the certificate is answered by the proxy itself and never relayed, but only if it is served at all
+	servesCertificate := configuration.InterceptHttps && isCertificateRequest(ctx.Req)
+
+	return configuration.GameHostsOnly && !IsGameHost(ctx.Req.Host) && !servesCertificate
+}
@@ -9,6 +9,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"math/big"
+	"net"
 	"path"
 	"time"
 
@@ -30,6 +31,72 @@ func setCA(rootCa tls.Certificate) error {
 	return nil
 }
 
+// LoadRootCA returns the CA of the proxy from certDir and creates a new one if there is none yet
+func LoadRootCA(certDir string) tls.Certificate {
+	return getRootCA(certDir)
+}
+
+// IssueCertificate creates a server certificate for the given host names and ip addresses that is signed
+// by rootCa. Clients that trust the proxy CA for HTTPS interception will also trust this certificate.
+func IssueCertificate(rootCa tls.Certificate, hosts []string) (tls.Certificate, error) {
+	caCert, err := x509.ParseCertificate(rootCa.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	notBefore := time.Now().Add(-10 * time.Second)
+	notAfter := notBefore.AddDate(1, 0, 0)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"swarpf v2"},
+			Locality:     []string{"Local Network"},
+		},
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	if len(hosts) > 0 {
+		template.Subject.CommonName = hosts[0]
+	}
+
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, caCert, &privKey.PublicKey, rootCa.PrivateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{derBytes, rootCa.Certificate[0]},
+		PrivateKey:  privKey,
+	}, nil
+}
+
 func getRootCA(certDir string) tls.Certificate {
 	appfs := afero.NewOsFs()
 
@@ -5,10 +5,37 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"net"
 	"net/http"
 	"strings"
 )
 
+// IsGameHost checks if host (with or without port) belongs to the Com2uS game servers
+func IsGameHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(host, ".")
+
+	return strings.HasPrefix(host, "summonerswar-") && strings.HasSuffix(host, "qpyou.cn")
+}
+
+// GameRegion returns the region of a game server host, e.g. `gb` for summonerswar-gb-lb.qpyou.cn
+func GameRegion(host string) string {
+	if !IsGameHost(host) {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	server := strings.TrimPrefix(strings.TrimSuffix(host, "."), "summonerswar-")
+	if idx := strings.Index(server, "-"); idx >= 0 {
+		return server[:idx]
+	}
+	return strings.TrimSuffix(server, ".qpyou.cn")
+}
+
 // Proxy Game Endpoint Matcher
 // used to determine if there's a CONNECT request to the Com2uS game server
 type proxyGameEndpointMatcher struct {
@@ -30,8 +57,7 @@ func (s proxyGameEndpointMatcher) HandleResp(_ *http.Response, ctx *goproxy.Prox
 func (s proxyGameEndpointMatcher) matches(ctx *goproxy.ProxyCtx) bool {
 	methodMatches := ctx.Req.Method == "CONNECT"
 
-	hostMatches := strings.HasPrefix(ctx.Req.Host, "summonerswar-") &&
-		(strings.HasSuffix(ctx.Req.Host, "qpyou.cn") || strings.HasSuffix(ctx.Req.Host, "qpyou.cn:443"))
+	hostMatches := IsGameHost(ctx.Req.Host)
 
 	if hostMatches {
 		log.Trace().
@@ -64,8 +90,7 @@ func (s locationServiceEndpointMatcher) HandleResp(_ *http.Response, ctx *goprox
 
 func (s locationServiceEndpointMatcher) matches(ctx *goproxy.ProxyCtx) bool {
 	methodMatches := ctx.Req.Method == "GET"
-	hostMatches := strings.HasPrefix(ctx.Req.Host, "summonerswar-") &&
-		strings.HasSuffix(ctx.Req.Host, "qpyou.cn")
+	hostMatches := IsGameHost(ctx.Req.Host)
 	urlMatches := ctx.Req.URL.Path == "/api/location_c2.php"
 
 	if hostMatches {
@@ -100,8 +125,7 @@ func (s gameEndpointMatcher) HandleResp(_ *http.Response, ctx *goproxy.ProxyCtx)
 
 func (s gameEndpointMatcher) matches(ctx *goproxy.ProxyCtx) bool {
 	methodMatches := ctx.Req.Method == "GET" || ctx.Req.Method == "POST"
-	hostMatches := strings.HasPrefix(ctx.Req.Host, "summonerswar-") &&
-		strings.HasSuffix(ctx.Req.Host, "qpyou.cn")
+	hostMatches := IsGameHost(ctx.Req.Host)
 	urlMatches := ctx.Req.URL.Path == "/api/gateway_c2.php"
 
 	if hostMatches {
@@ -137,5 +161,10 @@ func (s certificateEndpointMatcher) HandleResp(_ *http.Response, ctx *goproxy.Pr
 func (s certificateEndpointMatcher) matches(ctx *goproxy.ProxyCtx) bool {
 	log.Debug().Msg("matched for user requested certificate")
 
-	return ctx.Req.Method == "GET" && ctx.Req.URL.Path == "/ca.crt"
+	return isCertificateRequest(ctx.Req)
+}
+
+// isCertificateRequest checks if req is a download of the proxy CA, which is only served if HTTPS interception is enabled
+func isCertificateRequest(req *http.Request) bool {
+	return req.Method == "GET" && req.URL.Path == "/ca.crt"
 }
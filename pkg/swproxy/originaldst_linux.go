@@ -0,0 +1,45 @@
+//go:build linux
+// +build linux
+
+package swproxy
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"syscall"
+)
+
+// soOriginalDst is SO_ORIGINAL_DST from linux/netfilter_ipv4.h
+const soOriginalDst = 80
+
+// originalDestination returns the address a connection redirected by iptables REDIRECT was sent to. Connections
+// redirected with TPROXY keep their original destination as local address.
+func originalDestination(conn net.Conn) (string, error) {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return "", errors.New("not a tcp connection")
+	}
+	rawConn, err := tcpConn.SyscallConn()
+	if err != nil {
+		return "", err
+	}
+
+	var address string
+	var sockErr error
+	err = rawConn.Control(func(fd uintptr) {
+		// the struct sockaddr_in returned by SO_ORIGINAL_DST fits into the ipv6_mreq getter
+		mreq, err := syscall.GetsockoptIPv6Mreq(int(fd), syscall.IPPROTO_IP, soOriginalDst)
+		if err != nil {
+			sockErr = err
+			return
+		}
+		sockaddr := mreq.Multiaddr
+		port := int(sockaddr[2])<<8 | int(sockaddr[3])
+		address = net.JoinHostPort(net.IP(sockaddr[4:8]).String(), strconv.Itoa(port))
+	})
+	if err != nil {
+		return "", err
+	}
+	return address, sockErr
+}
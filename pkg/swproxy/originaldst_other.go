@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package swproxy
+
+import (
+	"errors"
+	"net"
+)
+
+// originalDestination is only supported on linux
+func originalDestination(net.Conn) (string, error) {
+	return "", errors.New("the original destination of redirected connections is only known on linux")
+}
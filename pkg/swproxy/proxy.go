@@ -2,11 +2,17 @@ package swproxy
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"errors"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	grpczerolog "github.com/cheapRoc/grpc-zerolog"
 	"github.com/elazarl/goproxy"
@@ -22,12 +28,31 @@ type ProxyConfiguration struct {
 	InterceptHttps       bool
 	ForceHttpDowngrade   bool `default:"false"`
 	Verbose              bool `default:"false"`
+	UpstreamProxies      []UpstreamProxyRule
+	AllowedNetworks      []*net.IPNet
+	ProxyUsername        string
+	ProxyPassword        string
+	GameHostsOnly        bool `default:"false"`
 }
 
 type Proxy struct {
-	log           zerolog.Logger
-	eventChan     chan events.ApiEventMsg
-	configuration ProxyConfiguration
+	// inflight counts the exchanges with the game api that were not completed yet. It is the first field
+	// to keep it 64-bit aligned for atomic operations.
+	inflight int64
+
+	log       zerolog.Logger
+	eventChan chan events.ApiEventMsg
+	router    *upstreamRouter
+
+	configurationMu sync.RWMutex
+	configuration   ProxyConfiguration
+
+	// eventChanMu is held for reading while events are sent, shutdown unblocks pending sends before closing
+	// the event channel
+	eventChanMu sync.RWMutex
+	closed      bool
+	shutdown    chan struct{}
+	once        sync.Once
 }
 
 // proxy.New : Create a new proxy instance for further use
@@ -41,22 +66,74 @@ func New(ev chan events.ApiEventMsg, configuration ProxyConfiguration) *Proxy {
 		log:           log.With().Timestamp().Str("log_type", "module").Str("module", "Proxy").Logger(),
 		eventChan:     ev,
 		configuration: configuration,
+		shutdown:      make(chan struct{}),
 	}
 }
 
+// config returns the current configuration, which can change at runtime
+func (p *Proxy) config() ProxyConfiguration {
+	p.configurationMu.RLock()
+	defer p.configurationMu.RUnlock()
+	return p.configuration
+}
+
+// UpdateConfiguration applies a changed configuration to the running proxy. CertificateDirectory and
+// InterceptHttps are only used by CreateProxy, so changes to them are ignored.
+func (p *Proxy) UpdateConfiguration(configuration ProxyConfiguration) error {
+	p.configurationMu.Lock()
+	defer p.configurationMu.Unlock()
+
+	configuration.CertificateDirectory = p.configuration.CertificateDirectory
+	configuration.InterceptHttps = p.configuration.InterceptHttps
+
+	if p.router != nil {
+		if err := p.router.setRules(configuration.UpstreamProxies); err != nil {
+			return err
+		}
+	}
+	p.configuration = configuration
+
+	p.log.Info().
+		Int("upstreamProxies", len(configuration.UpstreamProxies)).
+		Int("allowedNetworks", len(configuration.AllowedNetworks)).
+		Bool("authentication", configuration.ProxyUsername != "" || configuration.ProxyPassword != "").
+		Bool("gameHostsOnly", configuration.GameHostsOnly).
+		Bool("forceHttpDowngrade", configuration.ForceHttpDowngrade).
+		Msg("Applied updated proxy configuration")
+
+	return nil
+}
+
 func (p *Proxy) CreateProxy() http.Handler {
 	proxy := goproxy.NewProxyHttpServer()
 	proxy.Logger = grpczerolog.New(log.Logger) // todo(lyrex): this need some kind of better implementation that does not just throw everything into INFO
 	proxy.Verbose = p.configuration.Verbose
 
+	// the router is set up even without rules, so rules can be added at runtime
+	router, err := newUpstreamRouter(proxy, p.configuration.UpstreamProxies)
+	if err != nil {
+		p.log.Fatal().Err(err).Msg("could not set up upstream proxies")
+		return nil
+	}
+	proxy.Tr.Proxy = router.Proxy
+	proxy.ConnectDial = router.ConnectDial
+	p.router = router
+
+	for _, rule := range p.configuration.UpstreamProxies {
+		p.log.Info().
+			Str("host_pattern", rule.HostPattern).
+			Str("upstream_proxy", rule.String()).
+			Msg("Routing matching hosts through upstream proxy")
+	}
+
 	if p.configuration.ForceHttpDowngrade {
 		p.log.Warn().Msg("HTTPS -> HTTP downgrade is enabled")
-
-		// match the /api/location_c2.php endpoint and modify the body if necessary
-		proxy.OnResponse(newLocationServiceMatcher()).
-			DoFunc(p.onLocationResponse)
 	}
 
+	// match the /api/location_c2.php endpoint and modify the body if necessary
+	proxy.OnResponse(newLocationServiceMatcher()).
+		DoFunc(p.onLocationResponse)
+
 	if p.configuration.InterceptHttps {
 		p.log.Warn().Msg("HTTPS interception is enabled")
 
@@ -76,13 +153,35 @@ func (p *Proxy) CreateProxy() http.Handler {
 			})
 	}
 
+	if p.configuration.GameHostsOnly {
+		p.log.Info().Msg("Refusing to proxy hosts other than the game servers")
+	}
+
+	proxy.OnRequest(newNonGameEndpointMatcher(p)).HandleConnect(goproxy.AlwaysReject)
+	proxy.OnRequest(newNonGameEndpointMatcher(p)).DoFunc(
+		func(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
+			p.log.Warn().Str("host", req.Host).Msg("Refused to proxy request to a non-game host")
+			return req, goproxy.NewResponse(req, goproxy.ContentTypeText, http.StatusForbidden,
+				http.StatusText(http.StatusForbidden))
+		})
+
 	proxy.OnRequest(newGameEndpointMatcher()).
 		DoFunc(p.onRequest)
 
 	proxy.OnResponse(newGameEndpointMatcher()).
 		DoFunc(p.onResponse)
 
-	return proxy
+	return p.accessControl(proxy)
+}
+
+// exchange is the state of a request to the game api that is kept in goproxy.ProxyCtx.UserData until the response arrives
+type exchange struct {
+	done         sync.Once
+	rawRequest   string
+	plainRequest string
+	requestErr   error
+	requestSent  time.Time
+	metadata     events.ExchangeMetadata
 }
 
 func (p *Proxy) onRequest(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
@@ -93,6 +192,20 @@ func (p *Proxy) onRequest(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Reque
 		Interface("ctx.Req.Header", ctx.Req.Header).
 		Msg("New outgoing request")
 
+	ex := &exchange{metadata: events.ExchangeMetadata{
+		Session:        ctx.Session,
+		ClientAddr:     ctx.Req.RemoteAddr,
+		UpstreamHost:   ctx.Req.URL.Host,
+		UpstreamRegion: GameRegion(ctx.Req.URL.Host),
+		RequestStart:   time.Now(),
+	}}
+	ctx.UserData = ex
+	ctx.RoundTripper = goproxy.RoundTripperFunc(p.roundTrip)
+	atomic.AddInt64(&p.inflight, 1)
+	defer func() {
+		ex.requestSent = time.Now()
+	}()
+
 	if req == nil || req.ContentLength == 0 || req.Body == nil {
 		requestLogger.Info().Msg("Sending empty request to API")
 		return req, nil
@@ -100,24 +213,28 @@ func (p *Proxy) onRequest(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Reque
 	reqBody, err := ioutil.ReadAll(req.Body)
 	if err != nil {
 		requestLogger.Error().Err(err).Msg("could not read request body")
+		ex.requestErr = err
 		return req, nil
 	}
 
 	req.Body = ioutil.NopCloser(bytes.NewBuffer(reqBody))
+	ex.rawRequest = string(reqBody[:])
+	ex.metadata.RequestSize = len(reqBody)
 
-	reqContent := string(reqBody[:])
-	plainContent, err := p.readBody(reqContent, false)
+	plainContent, err := p.readBody(ex.rawRequest, false)
 	if err != nil {
 		// do not log here since we're logging the actual error in readBody
+		ex.requestErr = err
 		return req, nil
 	}
 
 	requestLogger.Trace().
-		Str("encryptedContent", reqContent).
+		Str("encryptedContent", ex.rawRequest).
 		Str("plainContent", plainContent).
 		Msg("Sending request from API")
 
-	ctx.UserData = plainContent
+	ex.plainRequest = plainContent
+	ex.metadata.RequestPlainSize = len(plainContent)
 
 	return req, nil
 }
@@ -125,27 +242,67 @@ func (p *Proxy) onRequest(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Reque
 func (p *Proxy) onResponse(resp *http.Response, ctx *goproxy.ProxyCtx) *http.Response {
 	responseLogger := p.log.With().Int64("ctx.Session", ctx.Session).Logger()
 
+	ex, ok := ctx.UserData.(*exchange)
+	if !ok {
+		ex = &exchange{metadata: events.ExchangeMetadata{Session: ctx.Session}}
+	}
+	if !ex.requestSent.IsZero() {
+		ex.metadata.UpstreamLatency = time.Since(ex.requestSent)
+	}
+
+	// failed round trips were already published and completed by roundTrip
+	if resp == nil {
+		responseLogger.Info().Err(ctx.Error).Msg("Received no response from API")
+		return resp
+	}
+	ex.metadata.StatusCode = resp.StatusCode
+	defer p.complete(ex)
+
 	responseLogger.Trace().
 		Stringer("ctx.Req.URL", ctx.Req.URL).
 		Interface("ctx.Req.Header", ctx.Req.Header).
 		Msg("New incoming response")
 
-	if resp == nil || resp.ContentLength == 0 || resp.Body == nil {
-		responseLogger.Info().Msg("Received empty reponse from API")
-		return resp
+	var respBody []byte
+	var readErr error
+	if resp.Body != nil {
+		respBody, readErr = ioutil.ReadAll(resp.Body)
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer(respBody))
 	}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		responseLogger.Error().Err(err).Msg("could not read response body")
+	respContent := string(respBody[:])
+	ex.metadata.ResponseSize = len(respBody)
+
+	// error statuses often come without a body, they must not be reported as empty responses
+	switch {
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		responseLogger.Warn().Int("statusCode", resp.StatusCode).Msg("API responded with an error status")
+		p.publishError(ex, events.StageUpstreamStatus, errors.New("upstream responded with "+resp.Status), respContent)
+		return resp
+	case resp.ContentLength == 0 || resp.Body == nil:
+		responseLogger.Info().Msg("Received empty reponse from API")
+		p.publishError(ex, events.StageEmptyResponse, errors.New("response has no body"), "")
+		return resp
+	case readErr != nil:
+		responseLogger.Error().Err(readErr).Msg("could not read response body")
+		p.publishError(ex, events.StageTruncated, readErr, respContent)
+		return resp
+	case resp.ContentLength > 0 && int64(len(respBody)) < resp.ContentLength:
+		responseLogger.Error().
+			Int64("contentLength", resp.ContentLength).
+			Int("bodyLength", len(respBody)).
+			Msg("response body is shorter than its content length")
+		p.publishError(ex, events.StageTruncated, io.ErrUnexpectedEOF, respContent)
+		return resp
+	case ex.requestErr != nil:
+		p.publishError(ex, events.StageRequestDecode, ex.requestErr, respContent)
 		return resp
 	}
-	resp.Body = ioutil.NopCloser(bytes.NewBuffer(respBody))
 
-	respContent := string(respBody[:])
 	responsePlainContent, err := p.readBody(respContent, true)
 	if err != nil {
 		// do not log here since we're logging the actual error in readBody
+		p.publishError(ex, events.StageResponseDecode, err, respContent)
 		return resp
 	}
 
@@ -154,18 +311,140 @@ func (p *Proxy) onResponse(resp *http.Response, ctx *goproxy.ProxyCtx) *http.Res
 		Str("plainContent", responsePlainContent).
 		Msg("Receiving response from API")
 
-	requestPlainContent := ctx.UserData.(string)
+	ex.metadata.ResponsePlainSize = len(responsePlainContent)
+	if !ex.metadata.RequestStart.IsZero() {
+		ex.metadata.Duration = time.Since(ex.metadata.RequestStart)
+	}
+
+	responseLogger.Debug().
+		Str("upstreamHost", ex.metadata.UpstreamHost).
+		Int("statusCode", ex.metadata.StatusCode).
+		Dur("upstreamLatency", ex.metadata.UpstreamLatency).
+		Dur("duration", ex.metadata.Duration).
+		Msg("Completed exchange with game api")
 
 	// send ApiEvent to event message
-	p.eventChan <- events.ApiEventMsg{
-		Request:  requestPlainContent,
+	p.publish(events.ApiEventMsg{
+		Request:  ex.plainRequest,
 		Response: responsePlainContent,
-	}
+		Metadata: ex.metadata,
+	})
 
 	return resp
 }
 
+// complete marks an exchange that was started by onRequest as completed
+func (p *Proxy) complete(ex *exchange) {
+	if ex.metadata.RequestStart.IsZero() {
+		return
+	}
+	ex.done.Do(func() {
+		atomic.AddInt64(&p.inflight, -1)
+	})
+}
+
+// roundTrip sends requests to the game api and publishes failed round trips as error events, since goproxy
+// does not pass them to the response handlers for intercepted HTTPS connections
+func (p *Proxy) roundTrip(req *http.Request, ctx *goproxy.ProxyCtx) (*http.Response, error) {
+	resp, err := ctx.Proxy.Tr.RoundTrip(req)
+	if err != nil {
+		ex, ok := ctx.UserData.(*exchange)
+		if !ok {
+			ex = &exchange{metadata: events.ExchangeMetadata{Session: ctx.Session}}
+		}
+		if !ex.requestSent.IsZero() {
+			ex.metadata.UpstreamLatency = time.Since(ex.requestSent)
+		}
+
+		stage := events.StageTransport
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			stage = events.StageTimeout
+		}
+
+		p.log.Error().Err(err).Int64("ctx.Session", ctx.Session).Str("stage", stage).Msg("could not reach API")
+		p.publishError(ex, stage, err, "")
+		p.complete(ex)
+	}
+
+	return resp, err
+}
+
+// publishError publishes a failed exchange as `error.<stage>` event. The event carries the plain request if it
+// could be decoded (the raw request otherwise) and the raw response body.
+func (p *Proxy) publishError(ex *exchange, stage string, err error, rawResponse string) {
+	request := ex.plainRequest
+	if request == "" {
+		request = ex.rawRequest
+	}
+
+	metadata := ex.metadata
+	metadata.Error = err.Error()
+	if !metadata.RequestStart.IsZero() {
+		metadata.Duration = time.Since(metadata.RequestStart)
+	}
+
+	p.publish(events.ApiEventMsg{
+		Command:  events.ErrorCommand(stage),
+		Request:  request,
+		Response: rawResponse,
+		Metadata: metadata,
+	})
+}
+
+// publish sends msg to the event channel unless the proxy was shut down
+func (p *Proxy) publish(msg events.ApiEventMsg) {
+	p.eventChanMu.RLock()
+	defer p.eventChanMu.RUnlock()
+
+	if p.closed {
+		p.log.Warn().Str("command", msg.Command).Msg("Dropping api event since the proxy was shut down")
+		return
+	}
+
+	select {
+	case p.eventChan <- msg:
+	case <-p.shutdown:
+		p.log.Warn().Str("command", msg.Command).Msg("Dropping api event since the proxy is shutting down")
+	}
+}
+
+// Shutdown waits until all in-flight exchanges with the game api are completed or ctx is done. Afterwards the
+// event channel is closed and exchanges that complete later are dropped. Listeners have to be closed before.
+func (p *Proxy) Shutdown(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	var err error
+	for atomic.LoadInt64(&p.inflight) > 0 && err == nil {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			p.log.Warn().
+				Int64("inflight", atomic.LoadInt64(&p.inflight)).
+				Msg("Stopped waiting for in-flight exchanges with the game api")
+		case <-ticker.C:
+		}
+	}
+
+	// sends that are still blocked by a slow receiver would keep the event channel from being closed
+	p.once.Do(func() { close(p.shutdown) })
+
+	p.eventChanMu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.eventChan)
+	}
+	p.eventChanMu.Unlock()
+
+	return err
+}
+
 func (p *Proxy) onLocationResponse(resp *http.Response, ctx *goproxy.ProxyCtx) *http.Response {
+	if !p.config().ForceHttpDowngrade {
+		return resp
+	}
+
 	responseLogger := p.log.With().
 		Int64("ctx.Session", ctx.Session).
 		Str("tag", "location_endpoint").
@@ -0,0 +1,125 @@
+package swproxy
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elazarl/goproxy"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+func TestOnResponseErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		contentLength int64
+		want          string
+	}{
+		{"error status without body", http.StatusServiceUnavailable, "", 0, events.StageUpstreamStatus},
+		{"error status with body", http.StatusBadGateway, "bad gateway", 11, events.StageUpstreamStatus},
+		{"empty response", http.StatusOK, "", 0, events.StageEmptyResponse},
+		{"truncated response", http.StatusOK, "short", 100, events.StageTruncated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan events.ApiEventMsg, 1)
+			p := New(ch, ProxyConfiguration{})
+
+			ctx := &goproxy.ProxyCtx{
+				Req:      httptest.NewRequest(http.MethodPost, "http://summonerswar-gb.qpyou.cn/api/gateway_c2.php", nil),
+				UserData: &exchange{},
+			}
+			resp := &http.Response{
+				StatusCode:    tt.statusCode,
+				Status:        http.StatusText(tt.statusCode),
+				ContentLength: tt.contentLength,
+				Body:          ioutil.NopCloser(strings.NewReader(tt.body)),
+			}
+			if tt.body == "" {
+				resp.Body = http.NoBody
+			}
+
+			p.onResponse(resp, ctx)
+
+			select {
+			case msg := <-ch:
+				if want := events.ErrorCommand(tt.want); msg.Command != want {
+					t.Errorf("published %s, want %s", msg.Command, want)
+				}
+				if msg.Metadata.StatusCode != tt.statusCode {
+					t.Errorf("status code = %d, want %d", msg.Metadata.StatusCode, tt.statusCode)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("no error event was published")
+			}
+		})
+	}
+}
+
+func TestShutdownWithBlockedPublisher(t *testing.T) {
+	// nobody receives from the event channel, so the send blocks
+	p := New(make(chan events.ApiEventMsg), ProxyConfiguration{})
+
+	published := make(chan struct{})
+	go func() {
+		p.publish(events.ApiEventMsg{Command: "HubUserLogin"})
+		close(published)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := p.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publish is still blocked after the shutdown")
+	}
+
+	// events of exchanges that complete after the shutdown are dropped
+	p.publish(events.ApiEventMsg{Command: "HubUserLogin"})
+}
+
+func TestGameHostsOnlyCertificateRequest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swproxy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	tests := []struct {
+		name           string
+		interceptHttps bool
+		want           int
+	}{
+		// nothing serves the certificate, the request would be relayed to any host
+		{"interception off", false, http.StatusForbidden},
+		{"interception on", true, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(make(chan events.ApiEventMsg, 1), ProxyConfiguration{
+				CertificateDirectory: dir,
+				InterceptHttps:       tt.interceptHttps,
+				GameHostsOnly:        true,
+			})
+
+			w := httptest.NewRecorder()
+			p.CreateProxy().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://any-host.example/ca.crt", nil))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
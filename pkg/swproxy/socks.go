@@ -0,0 +1,282 @@
+package swproxy
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SOCKS5 protocol constants (RFC 1928)
+const (
+	socksVersion5 = 0x05
+
+	socksMethodNoAuth       = 0x00
+	socksMethodUserPass     = 0x02
+	socksMethodNoAcceptable = 0xff
+
+	socksUserPassVersion = 0x01
+	socksUserPassSuccess = 0x00
+	socksUserPassFailure = 0x01
+
+	socksCommandConnect = 0x01
+
+	socksAddrTypeIPv4   = 0x01
+	socksAddrTypeDomain = 0x03
+	socksAddrTypeIPv6   = 0x04
+
+	socksReplySucceeded           = 0x00
+	socksReplyGeneralFailure      = 0x01
+	socksReplyNotAllowed          = 0x02
+	socksReplyHostUnreachable     = 0x04
+	socksReplyCommandNotSupported = 0x07
+	socksReplyAddrTypeNotSupport  = 0x08
+)
+
+// ServeSocks accepts SOCKS5 clients. HTTP and HTTPS connections to the game servers are fed into the same
+// pipeline as the http proxy, so they are intercepted by the game endpoint matchers. Everything else is
+// tunnelled untouched through the upstream proxies.
+func (p *Proxy) ServeSocks(l net.Listener, handler http.Handler) error {
+	ic := newInterceptor(p.log.With().Str("listener", "socks").Logger(), handler)
+	defer ic.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		if !p.isClientAllowed(conn.RemoteAddr().String()) {
+			p.log.Warn().Str("remote_addr", conn.RemoteAddr().String()).Msg("Rejected client outside of the allowed networks")
+			_ = conn.Close()
+			continue
+		}
+
+		go p.serveSocksConn(ic, conn)
+	}
+}
+
+func (p *Proxy) serveSocksConn(ic *interceptor, conn net.Conn) {
+	socksLogger := p.log.With().
+		Str("listener", "socks").
+		Str("remote_addr", conn.RemoteAddr().String()).
+		Logger()
+
+	_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+	br := bufio.NewReader(conn)
+	var checkCredentials func(username, password string) bool
+	if p.requiresAuthentication() {
+		checkCredentials = p.checkCredentials
+	}
+
+	target, err := socksHandshake(br, conn, checkCredentials)
+	if err != nil {
+		socksLogger.Debug().Err(err).Msg("SOCKS handshake failed")
+		_ = conn.Close()
+		return
+	}
+
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		_ = writeSocksReply(conn, socksReplyGeneralFailure)
+		_ = conn.Close()
+		return
+	}
+
+	// clients that resolve host names themselves are intercepted as well, the TLS server name or the Host
+	// header tells whether they connect to a game server
+	if (port == "80" || port == "443") && (IsGameHost(host) || net.ParseIP(host) != nil) {
+		if err := writeSocksReply(conn, socksReplySucceeded); err != nil {
+			_ = conn.Close()
+			return
+		}
+		_ = conn.SetDeadline(time.Time{})
+
+		socksLogger.Debug().Str("target", target).Msg("Intercepting SOCKS connection")
+		ic.serveConn(&peekedConn{Conn: conn, r: br}, target)
+		return
+	}
+
+	// game servers on other ports are tunnelled, they can't be intercepted but are no reason to refuse the client
+	if p.config().GameHostsOnly && !IsGameHost(host) {
+		socksLogger.Warn().Str("target", target).Msg("Refused SOCKS connection to a host that is not a game server")
+		_ = writeSocksReply(conn, socksReplyNotAllowed)
+		_ = conn.Close()
+		return
+	}
+
+	upstream, err := p.router.ConnectDial("tcp", target)
+	if err != nil {
+		socksLogger.Debug().Err(err).Str("target", target).Msg("Could not connect to SOCKS target")
+		_ = writeSocksReply(conn, socksReplyHostUnreachable)
+		_ = conn.Close()
+		return
+	}
+	if err := writeSocksReply(conn, socksReplySucceeded); err != nil {
+		_ = upstream.Close()
+		_ = conn.Close()
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	socksLogger.Debug().Str("target", target).Msg("Tunnelling SOCKS connection")
+	splice(&peekedConn{Conn: conn, r: br}, upstream)
+}
+
+// splice copies data between both connections until one of them is closed
+func splice(client, upstream net.Conn) {
+	done := make(chan struct{}, 2)
+	cp := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go cp(upstream, client)
+	go cp(client, upstream)
+
+	<-done
+	_ = client.Close()
+	_ = upstream.Close()
+	<-done
+}
+
+// socksHandshake authenticates the client if checkCredentials is set and returns the target of its CONNECT request
+func socksHandshake(r *bufio.Reader, w io.Writer, checkCredentials func(username, password string) bool) (string, error) {
+	// greeting: version, number of methods, methods
+	greeting := make([]byte, 2)
+	if _, err := io.ReadFull(r, greeting); err != nil {
+		return "", err
+	}
+	if greeting[0] != socksVersion5 {
+		return "", fmt.Errorf("unsupported SOCKS version %d", greeting[0])
+	}
+
+	methods := make([]byte, greeting[1])
+	if _, err := io.ReadFull(r, methods); err != nil {
+		return "", err
+	}
+
+	method := byte(socksMethodNoAuth)
+	if checkCredentials != nil {
+		method = socksMethodUserPass
+	}
+
+	if !containsByte(methods, method) {
+		_, _ = w.Write([]byte{socksVersion5, socksMethodNoAcceptable})
+		return "", errors.New("client does not support any of our authentication methods")
+	}
+	if _, err := w.Write([]byte{socksVersion5, method}); err != nil {
+		return "", err
+	}
+
+	if method == socksMethodUserPass {
+		if err := socksAuthenticate(r, w, checkCredentials); err != nil {
+			return "", err
+		}
+	}
+
+	// request: version, command, reserved, address type, address, port
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(r, request); err != nil {
+		return "", err
+	}
+	if request[0] != socksVersion5 {
+		return "", fmt.Errorf("unsupported SOCKS version %d", request[0])
+	}
+
+	var host string
+	switch request[3] {
+	case socksAddrTypeIPv4, socksAddrTypeIPv6:
+		ipLen := net.IPv4len
+		if request[3] == socksAddrTypeIPv6 {
+			ipLen = net.IPv6len
+		}
+		ip := make(net.IP, ipLen)
+		if _, err := io.ReadFull(r, ip); err != nil {
+			return "", err
+		}
+		host = ip.String()
+	case socksAddrTypeDomain:
+		domain, err := readSocksString(r)
+		if err != nil {
+			return "", err
+		}
+		host = domain
+	default:
+		_ = writeSocksReply(w, socksReplyAddrTypeNotSupport)
+		return "", fmt.Errorf("unsupported SOCKS address type %d", request[3])
+	}
+
+	portBytes := make([]byte, 2)
+	if _, err := io.ReadFull(r, portBytes); err != nil {
+		return "", err
+	}
+	port := binary.BigEndian.Uint16(portBytes)
+
+	if request[1] != socksCommandConnect {
+		_ = writeSocksReply(w, socksReplyCommandNotSupported)
+		return "", fmt.Errorf("unsupported SOCKS command %d", request[1])
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(port))), nil
+}
+
+// socksAuthenticate performs the username/password authentication (RFC 1929)
+func socksAuthenticate(r *bufio.Reader, w io.Writer, checkCredentials func(username, password string) bool) error {
+	version, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if version != socksUserPassVersion {
+		return fmt.Errorf("unsupported SOCKS authentication version %d", version)
+	}
+
+	username, err := readSocksString(r)
+	if err != nil {
+		return err
+	}
+	password, err := readSocksString(r)
+	if err != nil {
+		return err
+	}
+
+	if !checkCredentials(username, password) {
+		_, _ = w.Write([]byte{socksUserPassVersion, socksUserPassFailure})
+		return errors.New("invalid SOCKS credentials")
+	}
+
+	_, err = w.Write([]byte{socksUserPassVersion, socksUserPassSuccess})
+	return err
+}
+
+func readSocksString(r *bufio.Reader) (string, error) {
+	length, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	value := make([]byte, length)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// writeSocksReply sends a reply without a bound address, since clients don't need it for CONNECT
+func writeSocksReply(w io.Writer, reply byte) error {
+	_, err := w.Write([]byte{socksVersion5, reply, 0x00, socksAddrTypeIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
+
+func containsByte(haystack []byte, needle byte) bool {
+	for _, b := range haystack {
+		if b == needle {
+			return true
+		}
+	}
+	return false
+}
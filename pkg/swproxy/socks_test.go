@@ -0,0 +1,97 @@
+package swproxy
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/swarpf/proxy/pkg/events"
+)
+
+func TestSocksGameHostsOnly(t *testing.T) {
+	p := New(make(chan events.ApiEventMsg, 1), ProxyConfiguration{GameHostsOnly: true})
+	handler := p.CreateProxy()
+
+	dialed := make(chan string, 1)
+	p.router.defaultConnectDial = func(network, addr string) (net.Conn, error) {
+		dialed <- addr
+		client, upstream := net.Pipe()
+		_ = upstream.Close()
+		return client, nil
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() { _ = p.ServeSocks(l, handler) }()
+
+	tests := []struct {
+		name   string
+		host   string
+		port   int
+		reply  byte
+		dialed bool
+	}{
+		{"game host on another port", "summonerswar-gb.qpyou.cn", 7001, socksReplySucceeded, true},
+		{"other host", "example.com", 7001, socksReplyNotAllowed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := net.Dial("tcp", l.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+			if reply := socksConnect(t, conn, tt.host, tt.port); reply != tt.reply {
+				t.Errorf("reply = %d, want %d", reply, tt.reply)
+			}
+
+			select {
+			case addr := <-dialed:
+				if want := net.JoinHostPort(tt.host, strconv.Itoa(tt.port)); !tt.dialed || addr != want {
+					t.Errorf("dialed %s, want dialed = %v", addr, tt.dialed)
+				}
+			default:
+				if tt.dialed {
+					t.Error("the target was not dialed")
+				}
+			}
+		})
+	}
+}
+
+// socksConnect sends a CONNECT request without authentication and returns the reply of the proxy
+func socksConnect(t *testing.T, conn net.Conn, host string, port int) byte {
+	t.Helper()
+
+	if _, err := conn.Write([]byte{socksVersion5, 1, socksMethodNoAuth}); err != nil {
+		t.Fatal(err)
+	}
+	method := make([]byte, 2)
+	if _, err := io.ReadFull(conn, method); err != nil {
+		t.Fatal(err)
+	}
+	if method[1] != socksMethodNoAuth {
+		t.Fatalf("method = %d, want no authentication", method[1])
+	}
+
+	request := append([]byte{socksVersion5, socksCommandConnect, 0x00, socksAddrTypeDomain, byte(len(host))}, host...)
+	request = append(request, 0, 0)
+	binary.BigEndian.PutUint16(request[len(request)-2:], uint16(port))
+	if _, err := conn.Write(request); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := make([]byte, 10)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		t.Fatal(err)
+	}
+	return reply[1]
+}
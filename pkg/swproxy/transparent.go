@@ -0,0 +1,294 @@
+package swproxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	tlsRecordTypeHandshake = 0x16
+	// clientHelloTimeout is the time a client has to send the start of an HTTP request or its TLS ClientHello
+	clientHelloTimeout = 30 * time.Second
+)
+
+var errClientHelloRead = errors.New("client hello read")
+
+// ServeTransparent accepts connections that were redirected to the proxy (e.g. by iptables REDIRECT/TPROXY)
+// without the client knowing about the proxy. The target host is taken from the SNI of the TLS ClientHello
+// or from the Host header of plain HTTP requests. TLS clients without SNI are sent to the original destination
+// of the connection, which is only known on linux. TLS connections are handed to handler as a CONNECT request,
+// so the game endpoint matchers decide whether the connection is intercepted or tunnelled.
+func (p *Proxy) ServeTransparent(l net.Listener, handler http.Handler) error {
+	ic := newInterceptor(p.log.With().Str("listener", "transparent").Logger(), handler)
+	defer ic.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		if !p.isClientAllowed(conn.RemoteAddr().String()) {
+			p.log.Warn().Str("remote_addr", conn.RemoteAddr().String()).Msg("Rejected client outside of the allowed networks")
+			_ = conn.Close()
+			continue
+		}
+
+		go ic.serveConn(conn, "")
+	}
+}
+
+// interceptor feeds raw client connections into the goproxy handler as if they were sent through the http proxy
+type interceptor struct {
+	log       zerolog.Logger
+	handler   http.Handler
+	httpConns *connListener
+}
+
+func newInterceptor(logger zerolog.Logger, handler http.Handler) *interceptor {
+	ic := &interceptor{
+		log:       logger,
+		handler:   handler,
+		httpConns: newConnListener(),
+	}
+
+	// plain HTTP connections are served by a regular http server that turns the requests into proxy requests
+	go func() {
+		server := &http.Server{Handler: http.HandlerFunc(ic.serveHttp)}
+		if err := server.Serve(ic.httpConns); err != nil {
+			ic.log.Debug().Err(err).Msg("stopped serving intercepted http connections")
+		}
+	}()
+
+	return ic
+}
+
+func (ic *interceptor) Close() error {
+	return ic.httpConns.Close()
+}
+
+// serveConn dispatches conn depending on whether the client speaks TLS or plain HTTP. If target is empty
+// the target is derived from the connection contents.
+func (ic *interceptor) serveConn(conn net.Conn, target string) {
+	connLogger := ic.log.With().Str("remote_addr", conn.RemoteAddr().String()).Logger()
+
+	br := bufio.NewReader(conn)
+	// the deadline covers the whole ClientHello, so slow clients can't hold the connection open
+	_ = conn.SetReadDeadline(time.Now().Add(clientHelloTimeout))
+	header, err := br.Peek(1)
+	if err != nil {
+		connLogger.Debug().Err(err).Msg("could not read from intercepted connection")
+		_ = conn.Close()
+		return
+	}
+
+	if header[0] != tlsRecordTypeHandshake {
+		_ = conn.SetReadDeadline(time.Time{})
+		ic.httpConns.push(&peekedConn{Conn: conn, r: br, target: target})
+		return
+	}
+
+	var clientHello bytes.Buffer
+	serverName, err := readClientHelloServerName(io.TeeReader(br, &clientHello))
+	_ = conn.SetReadDeadline(time.Time{})
+	replayConn := &peekedConn{Conn: conn, r: io.MultiReader(&clientHello, br)}
+
+	if target == "" && err != nil {
+		// clients without SNI can only be routed by the address they connected to
+		originalTarget, dstErr := originalDestination(conn)
+		if dstErr != nil {
+			connLogger.Warn().Err(err).AnErr("originalDestinationErr", dstErr).
+				Msg("could not determine target host of intercepted TLS connection")
+			_ = conn.Close()
+			return
+		}
+		target = originalTarget
+	}
+
+	switch {
+	case target == "":
+		target = net.JoinHostPort(serverName, "443")
+	case err == nil:
+		// prefer the server name if the client resolved the host itself, so the endpoint matchers can match it
+		if host, port, splitErr := net.SplitHostPort(target); splitErr == nil && net.ParseIP(host) != nil {
+			target = net.JoinHostPort(serverName, port)
+		}
+	}
+
+	connLogger.Debug().Str("target", target).Str("sni", serverName).Msg("Intercepted TLS connection")
+
+	connectReq := &http.Request{
+		Method:     http.MethodConnect,
+		URL:        &url.URL{Opaque: target, Host: target},
+		Host:       target,
+		Header:     make(http.Header),
+		RemoteAddr: conn.RemoteAddr().String(),
+	}
+	ic.handler.ServeHTTP(&hijackResponseWriter{conn: replayConn}, markIntercepted(connectReq))
+}
+
+func (ic *interceptor) serveHttp(w http.ResponseWriter, req *http.Request) {
+	req.URL.Scheme = "http"
+	req.URL.Host = req.Host
+	if target := connTargetFromContext(req); target != "" {
+		req.URL.Host = target
+	}
+
+	ic.handler.ServeHTTP(w, markIntercepted(req))
+}
+
+func connTargetFromContext(req *http.Request) string {
+	conn, ok := req.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	if !ok {
+		return ""
+	}
+
+	if addr, ok := conn.(peekedAddr); ok {
+		return addr.target
+	}
+	return ""
+}
+
+// readClientHelloServerName reads a TLS ClientHello from r and returns the requested server name (SNI)
+func readClientHelloServerName(r io.Reader) (string, error) {
+	var serverName string
+	err := tls.Server(readOnlyConn{r: r}, &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverName = hello.ServerName
+			return nil, errClientHelloRead
+		},
+	}).Handshake()
+
+	if serverName != "" {
+		return serverName, nil
+	}
+	if err == nil || errors.Is(err, errClientHelloRead) {
+		return "", errors.New("client hello does not contain a server name")
+	}
+	return "", err
+}
+
+// readOnlyConn is used to parse a ClientHello without responding to the client
+type readOnlyConn struct {
+	r io.Reader
+}
+
+func (c readOnlyConn) Read(b []byte) (int, error)       { return c.r.Read(b) }
+func (c readOnlyConn) Write([]byte) (int, error)        { return 0, io.ErrClosedPipe }
+func (c readOnlyConn) Close() error                     { return nil }
+func (c readOnlyConn) LocalAddr() net.Addr              { return nil }
+func (c readOnlyConn) RemoteAddr() net.Addr             { return nil }
+func (c readOnlyConn) SetDeadline(time.Time) error      { return nil }
+func (c readOnlyConn) SetReadDeadline(time.Time) error  { return nil }
+func (c readOnlyConn) SetWriteDeadline(time.Time) error { return nil }
+
+// peekedConn replays the data that was already read to determine the connection type
+type peekedConn struct {
+	net.Conn
+	r      io.Reader
+	target string
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *peekedConn) LocalAddr() net.Addr {
+	return peekedAddr{Addr: c.Conn.LocalAddr(), target: c.target}
+}
+
+// peekedAddr carries an explicitly requested target (e.g. from a SOCKS request) to the http handler
+type peekedAddr struct {
+	net.Addr
+	target string
+}
+
+// hijackResponseWriter is used to hand a connection to goproxy as CONNECT request. goproxy confirms the
+// CONNECT request before it starts talking to the client, which the client does not expect and is dropped.
+type hijackResponseWriter struct {
+	conn net.Conn
+}
+
+func (w *hijackResponseWriter) Header() http.Header {
+	return make(http.Header)
+}
+
+func (w *hijackResponseWriter) Write(b []byte) (int, error) {
+	return w.conn.Write(b)
+}
+
+func (w *hijackResponseWriter) WriteHeader(int) {}
+
+func (w *hijackResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn := &connectOkFilterConn{Conn: w.conn}
+	return conn, bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn)), nil
+}
+
+var connectOkResponse = []byte("HTTP/1.0 200 OK\r\n\r\n")
+
+type connectOkFilterConn struct {
+	net.Conn
+	once sync.Once
+}
+
+func (c *connectOkFilterConn) Write(b []byte) (int, error) {
+	filtered := false
+	c.once.Do(func() {
+		filtered = bytes.Equal(b, connectOkResponse)
+	})
+	if filtered {
+		return len(b), nil
+	}
+
+	return c.Conn.Write(b)
+}
+
+// connListener is a net.Listener that returns connections pushed into it
+type connListener struct {
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newConnListener() *connListener {
+	return &connListener{
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+func (l *connListener) push(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.closed:
+		_ = conn.Close()
+	}
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, errors.New("listener closed")
+	}
+}
+
+func (l *connListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return &net.TCPAddr{}
+}